@@ -18,6 +18,9 @@ import (
 	"github.com/spf13/cobra"
 
 	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/phase"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
 	"opendev.org/airship/airshipctl/pkg/remote"
 )
 
@@ -65,3 +68,22 @@ func GetHostSelections(name string, labels string) []remote.HostSelector {
 
 	return selectors
 }
+
+// phaseBundle returns a document bundle built from the document entry point of the phase
+func phaseBundle(cfg *config.Config, phaseName string) (document.Bundle, error) {
+	helper, err := phase.NewHelper(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := phase.NewClient(helper).PhaseByID(ifc.ID{Name: phaseName})
+	if err != nil {
+		return nil, err
+	}
+
+	docRoot, err := p.DocumentRoot()
+	if err != nil {
+		return nil, err
+	}
+	return document.NewBundleByPath(docRoot)
+}
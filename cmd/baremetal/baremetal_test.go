@@ -20,6 +20,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"opendev.org/airship/airshipctl/cmd/baremetal"
+	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/testutil"
 )
 
@@ -81,3 +82,13 @@ func TestGetHostSelectionsNone(t *testing.T) {
 	selectors := baremetal.GetHostSelections("", "")
 	assert.Len(t, selectors, 0)
 }
+
+func TestBaremetalBadPhase(t *testing.T) {
+	cfgFactory := func() (*config.Config, error) {
+		return testutil.DummyConfig(), nil
+	}
+
+	cmd := baremetal.NewPowerOnCommand(cfgFactory)
+	cmd.SetArgs([]string{"--phase", "bad-phase"})
+	assert.Error(t, cmd.Execute())
+}
@@ -27,7 +27,7 @@ import (
 func NewRebootCommand(cfgFactory config.Factory) *cobra.Command {
 	var labels string
 	var name string
-	var phase string
+	var phaseName string
 
 	cmd := &cobra.Command{
 		Use:   "reboot",
@@ -39,8 +39,13 @@ func NewRebootCommand(cfgFactory config.Factory) *cobra.Command {
 				return err
 			}
 
+			docBundle, err := phaseBundle(cfg, phaseName)
+			if err != nil {
+				return err
+			}
+
 			selectors := GetHostSelections(name, labels)
-			m, err := remote.NewManager(cfg, phase, selectors...)
+			m, err := remote.NewManager(cfg, docBundle, selectors...)
 			if err != nil {
 				return err
 			}
@@ -60,7 +65,7 @@ func NewRebootCommand(cfgFactory config.Factory) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVarP(&labels, flagLabel, flagLabelShort, "", flagLabelDescription)
 	flags.StringVarP(&name, flagName, flagNameShort, "", flagNameDescription)
-	flags.StringVar(&phase, flagPhase, config.BootstrapPhase, flagPhaseDescription)
+	flags.StringVar(&phaseName, flagPhase, config.BootstrapPhase, flagPhaseDescription)
 
 	return cmd
 }
@@ -33,8 +33,13 @@ func NewRemoteDirectCommand(cfgFactory config.Factory) *cobra.Command {
 				return err
 			}
 
+			docBundle, err := phaseBundle(cfg, config.BootstrapPhase)
+			if err != nil {
+				return err
+			}
+
 			manager, err := remote.NewManager(cfg,
-				config.BootstrapPhase,
+				docBundle,
 				remote.ByLabel(document.EphemeralHostSelector))
 			if err != nil {
 				return err
@@ -45,7 +50,7 @@ func NewRemoteDirectCommand(cfgFactory config.Factory) *cobra.Command {
 			}
 
 			ephemeralHost := manager.Hosts[0]
-			return ephemeralHost.DoRemoteDirect(cfg)
+			return ephemeralHost.DoRemoteDirect(docBundle)
 		},
 	}
 
@@ -38,6 +38,7 @@ func NewClusterCommand(cfgFactory config.Factory) *cobra.Command {
 	}
 
 	clusterRootCmd.AddCommand(NewInitCommand(cfgFactory))
+	clusterRootCmd.AddCommand(NewListCommand(cfgFactory))
 	clusterRootCmd.AddCommand(NewMoveCommand(cfgFactory))
 	clusterRootCmd.AddCommand(NewStatusCommand(cfgFactory, client.DefaultClient))
 
@@ -0,0 +1,69 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cluster
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/cluster/graph"
+	"opendev.org/airship/airshipctl/pkg/config"
+)
+
+const (
+	listLong = `
+List clusters defined in the cluster map of the manifest as a tree of parent
+and child clusters. For each cluster the phases executed against it, whether
+its kubeconfig is dynamic and the source of the kubeconfig are shown. The
+cluster graph can be exported in Graphviz DOT and JSON formats.
+`
+	listExample = `
+# Print cluster tree
+airshipctl cluster list
+
+# Render cluster graph with Graphviz
+airshipctl cluster list -o dot | dot -Tsvg > clusters.svg
+
+# Export cluster graph in json format
+airshipctl cluster list -o json
+`
+)
+
+// NewListCommand creates a command which prints clusters defined in the cluster map
+func NewListCommand(cfgFactory config.Factory) *cobra.Command {
+	lc := &graph.ListCommand{
+		Options: graph.ListFlags{},
+		Factory: cfgFactory,
+	}
+
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List clusters defined in the cluster map",
+		Long:    listLong[1:],
+		Args:    cobra.NoArgs,
+		Example: listExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lc.Writer = cmd.OutOrStdout()
+			return lc.RunE()
+		},
+	}
+
+	listCmd.Flags().StringVarP(
+		&lc.Options.Output,
+		"output",
+		"o",
+		graph.TreeOutputFormat,
+		"output format, one of: tree, dot, json")
+	return listCmd
+}
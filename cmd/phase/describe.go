@@ -0,0 +1,56 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/phase"
+)
+
+const (
+	describeLong = `
+Show details of the life-cycle phase: its description, executor and summary
+of what the executor is going to do, cluster the phase is executed against
+and its parent cluster, document root and source of kubeconfig.
+`
+	describeExample = `
+# Describe initinfra phase
+airshipctl phase describe initinfra
+`
+)
+
+// NewDescribeCommand creates a command to show details of the phase
+func NewDescribeCommand(cfgFactory config.Factory) *cobra.Command {
+	dc := &phase.DescribeCommand{
+		Options: phase.DescribeFlags{},
+		Factory: cfgFactory,
+	}
+
+	describeCmd := &cobra.Command{
+		Use:     "describe PHASE_NAME",
+		Short:   "Describe phase",
+		Long:    describeLong[1:],
+		Args:    cobra.ExactArgs(1),
+		Example: describeExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dc.Options.PhaseID.Name = args[0]
+			dc.Writer = cmd.OutOrStdout()
+			return dc.RunE()
+		},
+	}
+	return describeCmd
+}
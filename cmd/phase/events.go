@@ -0,0 +1,77 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/phase"
+)
+
+const (
+	eventsLong = `
+Inspect events of phase runs recorded with the events-file flag of phase run
+and plan run commands.
+`
+	eventsReplayLong = `
+Print events recorded in the events file the same way they were printed during
+the phase run, or in another output format. Command fails if the recorded
+events contain errors.
+`
+	eventsReplayExample = `
+# Record events of the phase run and print them again later
+airshipctl phase run initinfra --events-file initinfra-events.json
+airshipctl phase events replay initinfra-events.json
+
+# Print recorded events as JSON objects, one per line
+airshipctl phase events replay initinfra-events.json --output json
+`
+)
+
+// NewEventsCommand creates a command for inspecting recorded phase events
+func NewEventsCommand() *cobra.Command {
+	eventsCmd := &cobra.Command{
+		Use:   "events",
+		Short: "Inspect recorded phase events",
+		Long:  eventsLong[1:],
+	}
+	eventsCmd.AddCommand(NewEventsReplayCommand())
+	return eventsCmd
+}
+
+// NewEventsReplayCommand creates a command which prints events recorded in the events file
+func NewEventsReplayCommand() *cobra.Command {
+	p := &phase.EventsReplayCommand{}
+
+	replayCmd := &cobra.Command{
+		Use:     "replay FILE",
+		Short:   "Print recorded phase events",
+		Long:    eventsReplayLong[1:],
+		Args:    cobra.ExactArgs(1),
+		Example: eventsReplayExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p.Options.File = args[0]
+			return p.RunE()
+		},
+	}
+	flags := replayCmd.Flags()
+	flags.StringVarP(
+		&p.Options.Output,
+		"output",
+		"o",
+		phase.TextEventFormat,
+		"output format of phase events, one of: text, json")
+	return replayCmd
+}
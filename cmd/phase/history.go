@@ -0,0 +1,85 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/phase"
+)
+
+const (
+	historyLong = `
+List phase runs saved in the local history. Every phase run records the phase
+name, cluster name, start and end time, result, errors and git revision of the
+manifest target path. History is kept in the airship config directory.
+`
+	historyExample = `
+# List all phase runs
+airshipctl phase history
+
+# List failed runs of initinfra phase during the last day
+airshipctl phase history --phase initinfra --result Failed --since 24h
+
+# List 10 latest runs against target-cluster
+airshipctl phase history --cluster target-cluster --limit 10
+`
+)
+
+// NewHistoryCommand creates a command which prints history of phase runs
+func NewHistoryCommand(cfgFactory config.Factory) *cobra.Command {
+	hc := &phase.HistoryCommand{Factory: cfgFactory}
+
+	historyCmd := &cobra.Command{
+		Use:     "history",
+		Short:   "List phase runs",
+		Long:    historyLong[1:],
+		Args:    cobra.NoArgs,
+		Example: historyExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hc.Writer = cmd.OutOrStdout()
+			return hc.RunE()
+		},
+	}
+
+	flags := historyCmd.Flags()
+	flags.StringVar(
+		&hc.Options.PhaseName,
+		"phase",
+		"",
+		"filter phase runs by phase name")
+	flags.StringVar(
+		&hc.Options.ClusterName,
+		"cluster",
+		"",
+		"filter phase runs by cluster name")
+	flags.StringVar(
+		&hc.Options.Result,
+		"result",
+		"",
+		"filter phase runs by result, one of: Succeeded, Failed")
+	flags.DurationVar(
+		&hc.Options.Since,
+		"since",
+		0,
+		"show only phase runs started within the given duration, e.g. 1h or 30m")
+	flags.IntVar(
+		&hc.Options.Limit,
+		"limit",
+		0,
+		"maximum number of the latest phase runs to show, all runs are shown if not set")
+	return historyCmd
+}
@@ -0,0 +1,80 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/phase"
+)
+
+const (
+	listLong = `
+List life-cycle phases defined in the phase metadata path of the manifest.
+Phases can be filtered by labels and cluster name. Output is printed as a
+table by default, yaml and json formats can be used for scripting.
+`
+	listExample = `
+# List all phases
+airshipctl phase list
+
+# List phases executed against target-cluster in yaml format
+airshipctl phase list --cluster-name target-cluster -o yaml
+
+# List phases with label "app=helm"
+airshipctl phase list -l app=helm
+`
+)
+
+// NewListCommand creates a command which prints phases defined in the manifest
+func NewListCommand(cfgFactory config.Factory) *cobra.Command {
+	lc := &phase.ListCommand{
+		Options: phase.ListFlags{},
+		Factory: cfgFactory,
+	}
+
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List phases defined in manifest",
+		Long:    listLong[1:],
+		Args:    cobra.NoArgs,
+		Example: listExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lc.Writer = cmd.OutOrStdout()
+			return lc.RunE()
+		},
+	}
+
+	flags := listCmd.Flags()
+	flags.StringVarP(
+		&lc.Options.Label,
+		"label",
+		"l",
+		"",
+		"filter phases by label selector")
+	flags.StringVar(
+		&lc.Options.ClusterName,
+		"cluster-name",
+		"",
+		"filter phases by cluster name")
+	flags.StringVarP(
+		&lc.Options.Output,
+		"output",
+		"o",
+		phase.TableOutputFormat,
+		"output format, one of: table, yaml, json")
+	return listCmd
+}
@@ -38,6 +38,11 @@ func NewPhaseCommand(cfgFactory config.Factory) *cobra.Command {
 	phaseRootCmd.AddCommand(NewRenderCommand(cfgFactory))
 	phaseRootCmd.AddCommand(NewPlanCommand(cfgFactory))
 	phaseRootCmd.AddCommand(NewRunCommand(cfgFactory))
+	phaseRootCmd.AddCommand(NewHistoryCommand(cfgFactory))
+	phaseRootCmd.AddCommand(NewEventsCommand())
+	phaseRootCmd.AddCommand(NewValidateCommand(cfgFactory))
+	phaseRootCmd.AddCommand(NewDescribeCommand(cfgFactory))
+	phaseRootCmd.AddCommand(NewListCommand(cfgFactory))
 
 	return phaseRootCmd
 }
@@ -28,6 +28,18 @@ const (
 	runExample = `
 # Run initinfra phase
 airshipctl phase run ephemeral-control-plane
+
+# Show changes the phase would make to the cluster without applying them
+airshipctl phase run initinfra --diff
+
+# Cancel the phase if it's not completed in 1 hour
+airshipctl phase run ephemeral-control-plane --timeout 1h
+
+# Print phase events as JSON objects, one per line
+airshipctl phase run ephemeral-control-plane --output json
+
+# Record phase events to the file, so that they can be replayed later
+airshipctl phase run ephemeral-control-plane --events-file events.json
 `
 )
 
@@ -55,5 +67,26 @@ func NewRunCommand(cfgFactory config.Factory) *cobra.Command {
 		"dry-run",
 		false,
 		"simulate phase execution")
+	flags.BoolVar(
+		&p.Options.Diff,
+		"diff",
+		false,
+		"show unified diff between phase documents and live objects, supported by KubernetesApply phases only")
+	flags.DurationVar(
+		&p.Options.Timeout,
+		"timeout",
+		0,
+		"maximum duration of the phase run, e.g. 30m, the run is not limited in time if not set")
+	flags.StringVarP(
+		&p.Options.Output,
+		"output",
+		"o",
+		phase.TextEventFormat,
+		"output format of phase events, one of: text, json")
+	flags.StringVar(
+		&p.Options.EventsFile,
+		"events-file",
+		"",
+		"path to the file where phase events are recorded, see 'airshipctl phase events replay'")
 	return runCmd
 }
@@ -0,0 +1,63 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/phase"
+)
+
+const (
+	validateLong = `
+Validate life-cycle phase configuration without executing it. Validation makes
+sure that executor document of the phase exists and its kind is supported,
+document entrypoint can be built, phase cluster is defined in the cluster map
+and executor configuration is correct. If phase name is not specified, all
+phases defined in the phase plan are validated.
+`
+	validateExample = `
+# Validate initinfra phase
+airshipctl phase validate initinfra
+
+# Validate all phases defined in the plan
+airshipctl phase validate
+`
+)
+
+// NewValidateCommand creates a command to validate phase configuration
+func NewValidateCommand(cfgFactory config.Factory) *cobra.Command {
+	vc := &phase.ValidateCommand{
+		Options: phase.ValidateFlags{},
+		Factory: cfgFactory,
+	}
+
+	validateCmd := &cobra.Command{
+		Use:     "validate [PHASE_NAME]",
+		Short:   "Validate phase",
+		Long:    validateLong[1:],
+		Args:    cobra.MaximumNArgs(1),
+		Example: validateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				vc.Options.PhaseID.Name = args[0]
+			}
+			vc.Writer = cmd.OutOrStdout()
+			return vc.RunE()
+		},
+	}
+	return validateCmd
+}
@@ -0,0 +1,107 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package plan
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/phase"
+)
+
+const (
+	runLong = `
+Run all phases defined in the phase plan. Phases within a group are executed
+sequentially, a phase may also depend on phases from other groups listed in
+its dependsOn field. Phases which don't depend on each other are executed
+simultaneously, up to the limit set by the concurrency flag. No new phases
+are started after the first failure, a summary of executed phases is printed
+at the end.
+`
+	runExample = `
+# Run all phases defined in the plan
+airshipctl plan run
+
+# Resume plan execution starting from initinfra-target phase
+airshipctl plan run --start-at initinfra-target
+
+# Run phases up to and including initinfra-ephemeral phase
+airshipctl plan run --stop-after initinfra-ephemeral
+
+# Run up to 3 independent phases simultaneously
+airshipctl plan run --concurrency 3
+
+# Print events of all phases as JSON objects, one per line
+airshipctl plan run --output json
+`
+)
+
+// NewRunCommand creates a command to run all phases defined in the plan
+func NewRunCommand(cfgFactory config.Factory) *cobra.Command {
+	p := &phase.PlanRunCommand{
+		Options: phase.PlanRunFlags{},
+		Factory: cfgFactory,
+	}
+
+	runCmd := &cobra.Command{
+		Use:     "run",
+		Short:   "Run plan",
+		Long:    runLong[1:],
+		Args:    cobra.NoArgs,
+		Example: runExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p.Writer = cmd.OutOrStdout()
+			return p.RunE()
+		},
+	}
+	flags := runCmd.Flags()
+	flags.BoolVar(
+		&p.Options.DryRun,
+		"dry-run",
+		false,
+		"simulate phase execution")
+	flags.StringVar(
+		&p.Options.StartAt,
+		"start-at",
+		"",
+		"name of the phase to start plan execution from, preceding phases are skipped")
+	flags.StringVar(
+		&p.Options.StopAfter,
+		"stop-after",
+		"",
+		"name of the last phase to execute, following phases are skipped")
+	flags.IntVar(
+		&p.Options.Concurrency,
+		"concurrency",
+		1,
+		"maximum number of phases executed simultaneously")
+	flags.DurationVar(
+		&p.Options.Timeout,
+		"timeout",
+		0,
+		"maximum duration of each phase run, e.g. 30m, phase runs are not limited in time if not set")
+	flags.StringVarP(
+		&p.Options.Output,
+		"output",
+		"o",
+		phase.TextEventFormat,
+		"output format of phase events, one of: text, json, summary is printed in text format only")
+	flags.StringVar(
+		&p.Options.EventsFile,
+		"events-file",
+		"",
+		"path to the file where events of all phases are recorded, see 'airshipctl phase events replay'")
+	return runCmd
+}
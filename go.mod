@@ -18,6 +18,7 @@ require (
 	github.com/metal3-io/baremetal-operator v0.0.0-20200501205115-2c0dc9997bfa
 	github.com/onsi/gomega v1.9.0
 	github.com/pkg/errors v0.9.1
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/spf13/cobra v1.0.0
 	github.com/stretchr/testify v1.4.0
 	golang.org/x/tools v0.0.0-20200619210111-0f592d2728bb // indirect
@@ -0,0 +1,76 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BaremetalOperation is an out-of-band operation performed on a baremetal host
+type BaremetalOperation string
+
+// BaremetalPowerState is a power state of a baremetal host to wait for
+type BaremetalPowerState string
+
+const (
+	// BaremetalOperationPowerOn powers on the host
+	BaremetalOperationPowerOn BaremetalOperation = "power-on"
+	// BaremetalOperationPowerOff powers off the host
+	BaremetalOperationPowerOff BaremetalOperation = "power-off"
+	// BaremetalOperationReboot reboots the host
+	BaremetalOperationReboot BaremetalOperation = "reboot"
+	// BaremetalOperationEjectVirtualMedia ejects all media attached to the host
+	BaremetalOperationEjectVirtualMedia BaremetalOperation = "eject-virtual-media"
+	// BaremetalOperationRemoteDirect bootstraps the host with the image defined by RemoteDirectConfiguration
+	BaremetalOperationRemoteDirect BaremetalOperation = "remote-direct"
+
+	// BaremetalPowerStateOn host is powered on
+	BaremetalPowerStateOn BaremetalPowerState = "on"
+	// BaremetalPowerStateOff host is powered off
+	BaremetalPowerStateOff BaremetalPowerState = "off"
+)
+
+// +kubebuilder:object:root=true
+
+// BaremetalManager allows to perform out-of-band operations on baremetal hosts defined in the phase documents
+type BaremetalManager struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BaremetalManagerSpec `json:"spec"`
+}
+
+// BaremetalManagerSpec defines hosts to manage and operations to perform on them
+type BaremetalManagerSpec struct {
+	HostSelector BaremetalHostSelector `json:"hostSelector"`
+	// Steps are performed in the order they are defined, every step is performed on all selected hosts
+	Steps []BaremetalManagerStep `json:"steps"`
+}
+
+// BaremetalHostSelector selects BareMetalHost documents by name and labels,
+// hosts matching both criteria are selected if both are set
+type BaremetalHostSelector struct {
+	Name          string `json:"name,omitempty"`
+	LabelSelector string `json:"labelSelector,omitempty"`
+}
+
+// BaremetalManagerStep defines a single operation and an optional power state to wait for after it
+type BaremetalManagerStep struct {
+	Operation BaremetalOperation `json:"operation"`
+	// WaitForPowerState is a power state, either on or off, the host must reach before the next step is started
+	WaitForPowerState BaremetalPowerState `json:"waitForPowerState,omitempty"`
+	// Timeout in seconds to wait for the power state, defaults to 5 minutes
+	Timeout int `json:"timeout,omitempty"`
+}
@@ -24,7 +24,8 @@ import (
 type ClusterMap struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
-	// Keys in this map MUST correspond to context names in kubeconfigs provided
+	// Keys in this map MUST correspond to context names in kubeconfigs provided,
+	// unless kubeconfig context is explicitly defined for the cluster
 	Map map[string]*Cluster `json:"map,omitempty"`
 }
 
@@ -35,4 +36,12 @@ type Cluster struct {
 	// DynamicKubeConfig kubeconfig allows to get kubeconfig from parent cluster, instead
 	// expecting it to be in document bundle. Parent kubeconfig will be used to get kubeconfig
 	DynamicKubeConfig bool `json:"dynamicKubeConf,omitempty"`
+	// Namespace is a namespace of CAPI Cluster object and its kubeconfig secret in the parent cluster,
+	// default namespace is used if not set
+	Namespace string `json:"namespace,omitempty"`
+	// KubeconfigContext is a name of the cluster context in kubeconfig, map key is used if not set
+	KubeconfigContext string `json:"kubeconfigContext,omitempty"`
+	// KubeconfigSecretName is a name of the secret with cluster kubeconfig in the parent cluster,
+	// <map key>-kubeconfig secret created by CAPI is used if not set
+	KubeconfigSecretName string `json:"kubeconfigSecretName,omitempty"`
 }
@@ -0,0 +1,67 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GenericContainerOutputType defines what is done with the documents produced by the container
+type GenericContainerOutputType string
+
+const (
+	// GenericContainerOutputApply output documents are applied to kubernetes cluster
+	GenericContainerOutputApply GenericContainerOutputType = "apply"
+	// GenericContainerOutputDirectory output documents are written to a directory along with
+	// kustomization.yaml, so the directory can be used as document entrypoint of the next phase
+	GenericContainerOutputDirectory GenericContainerOutputType = "directory"
+)
+
+// +kubebuilder:object:root=true
+
+// GenericContainer provides instructions on how to run a KRM function container against
+// documents of the phase and what to do with the documents returned by the function
+type GenericContainer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GenericContainerSpec   `json:"spec,omitempty"`
+	Output GenericContainerOutput `json:"output,omitempty"`
+	// Config is a YAML document passed to the function as functionConfig of the ResourceList
+	Config string `json:"config,omitempty"`
+}
+
+// GenericContainerSpec defines the container to run
+type GenericContainerSpec struct {
+	// Container image URL
+	Image string `json:"image,omitempty"`
+	// Container Runtime Interface driver
+	ContainerRuntime string `json:"containerRuntime,omitempty"`
+	// Command to execute, default command of the image is used if not set
+	Command []string `json:"command,omitempty"`
+	// Container volume bindings in host-path:container-path format
+	Mounts []string `json:"mounts,omitempty"`
+	// Environment variables in KEY=VALUE format
+	EnvVars []string `json:"envVars,omitempty"`
+}
+
+// GenericContainerOutput defines how output documents of the container are handled
+type GenericContainerOutput struct {
+	Type GenericContainerOutputType `json:"type,omitempty"`
+	// Path to the output directory relative to the target path, used with directory type
+	Path string `json:"path,omitempty"`
+	// Apply options used with apply type
+	Apply ApplyConfig `json:"apply,omitempty"`
+}
@@ -46,9 +46,12 @@ func init() {
 		&PhasePlan{},
 		&KubeConfig{},
 		&KubernetesApply{},
+		&KubernetesWait{},
 		&ImageConfiguration{},
+		&GenericContainer{},
 		&RemoteDirectConfiguration{},
 		&ClusterMap{},
+		&BaremetalManager{},
 	)
 	_ = AddToScheme(Scheme) //nolint:errcheck
 }
@@ -30,8 +30,10 @@ type KubernetesApply struct {
 
 // ApplyConfig provides instructions on how to apply resources to kubernetes cluster
 type ApplyConfig struct {
-	WaitOptions  ApplyWaitOptions  `json:"waitOptions,omitempty"`
-	PruneOptions ApplyPruneOptions `json:"pruneOptions,omitempty"`
+	WaitOptions       ApplyWaitOptions       `json:"waitOptions,omitempty"`
+	PruneOptions      ApplyPruneOptions      `json:"pruneOptions,omitempty"`
+	ServerSideOptions ApplyServerSideOptions `json:"serverSideOptions,omitempty"`
+	InventoryOptions  ApplyInventoryOptions  `json:"inventoryOptions,omitempty"`
 }
 
 // ApplyWaitOptions provides instructions how to wait for kubernetes resources
@@ -44,3 +46,32 @@ type ApplyWaitOptions struct {
 type ApplyPruneOptions struct {
 	Prune bool `json:"prune,omitempty"`
 }
+
+// ApplyServerSideOptions provides instructions how to apply kubernetes resources using server-side apply
+type ApplyServerSideOptions struct {
+	// ServerSide enables server-side apply, objects are merged by kubernetes API server and
+	// last-applied-configuration annotation is not set, so it's not limited in size
+	ServerSide bool `json:"serverSide,omitempty"`
+	// FieldManager is a name of the manager which owns applied fields, airshipctl is used if not set
+	FieldManager string `json:"fieldManager,omitempty"`
+	// ForceConflicts makes airshipctl take ownership of the fields managed by other field managers
+	ForceConflicts bool `json:"forceConflicts,omitempty"`
+}
+
+// ApplyInventoryOptions provides instructions how to keep track of the applied kubernetes resources.
+// Inventory is a config map listing applied resources, it's used to find resources to prune
+type ApplyInventoryOptions struct {
+	// Namespace of the inventory config map, airshipit is used if not set
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the inventory config map, airshipit-<id> is used if not set
+	Name string `json:"name,omitempty"`
+	// ID identifies the inventory in the cluster, name of the phase is used if not set
+	ID string `json:"id,omitempty"`
+	// Labels are added to the inventory config map
+	Labels map[string]string `json:"labels,omitempty"`
+	// AdoptFrom lists inventory IDs used before, e.g. until the phase was renamed. If inventory with
+	// the current ID doesn't exist in the cluster, inventory found by one of these IDs is adopted,
+	// so that resources applied before are neither orphaned nor pruned. Previous inventories are
+	// searched in the namespace of the current inventory only
+	AdoptFrom []string `json:"adoptFrom,omitempty"`
+}
@@ -0,0 +1,56 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// KubernetesWait provides instructions on how to wait for kubernetes resources to reach desired status
+type KubernetesWait struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Config WaitConfig `json:"config,omitempty"`
+}
+
+// WaitConfig defines resources to wait for and how long to wait
+type WaitConfig struct {
+	// Selectors select documents of the phase, resources defined by the selected documents are waited for
+	Selectors []WaitSelector `json:"selectors,omitempty"`
+	// Resources are references to the objects to wait for, they don't have to be defined in the phase documents
+	Resources []corev1.ObjectReference `json:"resources,omitempty"`
+	// Status is a status all resources must reach, Current is used if not set.
+	// Custom statuses defined with airshipit.org/status-check CRD annotation are supported
+	Status string `json:"status,omitempty"`
+	// Timeout in seconds, wait indefinitely if not set
+	Timeout int `json:"timeout,omitempty"`
+	// PollInterval in seconds, defaults to 5 seconds
+	PollInterval int `json:"pollInterval,omitempty"`
+}
+
+// WaitSelector selects documents by GVK, name, namespace, labels and annotations
+type WaitSelector struct {
+	Group              string `json:"group,omitempty"`
+	Version            string `json:"version,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+	Name               string `json:"name,omitempty"`
+	Namespace          string `json:"namespace,omitempty"`
+	LabelSelector      string `json:"labelSelector,omitempty"`
+	AnnotationSelector string `json:"annotationSelector,omitempty"`
+}
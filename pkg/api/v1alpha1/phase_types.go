@@ -25,7 +25,9 @@ import (
 type Phase struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
-	Config            PhaseConfig `json:"config,omitempty"`
+	// Description is an optional human readable description of the phase
+	Description string      `json:"description,omitempty"`
+	Config      PhaseConfig `json:"config,omitempty"`
 }
 
 // PhaseConfig represents configuration for a particular phase. It contains a reference to
@@ -33,4 +35,66 @@ type Phase struct {
 type PhaseConfig struct {
 	ExecutorRef        *corev1.ObjectReference `json:"executorRef"`
 	DocumentEntryPoint string                  `json:"documentEntryPoint"`
+	// PreRun hooks are executed one by one before the phase executor is run
+	PreRun []PhaseHook `json:"preRun,omitempty"`
+	// PostRun hooks are executed one by one after the phase executor has succeeded
+	PostRun []PhaseHook `json:"postRun,omitempty"`
+	// Retry defines how phase executor is re-run after a failure, executor is run once if it's not set
+	Retry *PhaseRetryPolicy `json:"retry,omitempty"`
+}
+
+// PhaseHookFailurePolicy defines how the failure of a phase hook is handled
+type PhaseHookFailurePolicy string
+
+const (
+	// PhaseHookFailurePolicyFail fails the phase if the hook has failed, this is the default policy
+	PhaseHookFailurePolicyFail PhaseHookFailurePolicy = "Fail"
+	// PhaseHookFailurePolicyWarn reports the failure of the hook and continues the phase execution
+	PhaseHookFailurePolicyWarn PhaseHookFailurePolicy = "Warn"
+)
+
+// PhaseHook is an action executed before or after the phase executor, it either runs
+// another phase or a local command. Exactly one of Phase and Command must be set
+type PhaseHook struct {
+	// Name of the hook used in events and error messages, phase name or
+	// command executable is used if it's not set
+	Name string `json:"name,omitempty"`
+	// Phase is a name of the phase to run, the phase must be in the same namespace
+	Phase string `json:"phase,omitempty"`
+	// Command is a local command to execute, the first element is an executable.
+	// Command is executed from the target path of the manifest and is skipped in dry-run mode
+	Command []string `json:"command,omitempty"`
+	// FailurePolicy is one of Fail or Warn, Fail is used if it's not set
+	FailurePolicy PhaseHookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// PhaseRetryErrorType is a class of errors which can be retried
+type PhaseRetryErrorType string
+
+const (
+	// PhaseRetryOnAny retries any error
+	PhaseRetryOnAny PhaseRetryErrorType = "Any"
+	// PhaseRetryOnNetwork retries errors of network connections, e.g. connection refused or reset
+	PhaseRetryOnNetwork PhaseRetryErrorType = "Network"
+	// PhaseRetryOnTimeout retries expired timeouts of requests and waits for resources
+	PhaseRetryOnTimeout PhaseRetryErrorType = "Timeout"
+	// PhaseRetryOnServerError retries kubernetes API server errors with 5xx or 429 codes
+	PhaseRetryOnServerError PhaseRetryErrorType = "ServerError"
+	// PhaseRetryOnConflict retries kubernetes API conflicts
+	PhaseRetryOnConflict PhaseRetryErrorType = "Conflict"
+)
+
+// PhaseRetryPolicy defines how many times and when the phase executor is re-run after a failure.
+// Phase hooks are not retried, pre-run hooks are executed once before the first attempt
+// and post-run hooks are executed once after the successful attempt
+type PhaseRetryPolicy struct {
+	// MaxAttempts is a maximum number of executor runs including the first one
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+	// Backoff is a delay in seconds before the first retry, the delay is doubled for every next retry
+	Backoff int `json:"backoff,omitempty"`
+	// MaxBackoff is a maximum delay in seconds between retries, the delay is not limited if it's not set
+	MaxBackoff int `json:"maxBackoff,omitempty"`
+	// RetryOn is a list of error types which are retried, one of Any, Network, Timeout, ServerError
+	// or Conflict. Any error is retried if it's not set
+	RetryOn []PhaseRetryErrorType `json:"retryOn,omitempty"`
 }
@@ -37,4 +37,7 @@ type PhaseGroup struct {
 // PhaseGroupStep represents phase (or step) within phase group
 type PhaseGroupStep struct {
 	Name string `json:"name,omitempty"`
+	// DependsOn is a list of phase names defined in the same plan that must succeed
+	// before this phase is started, in addition to the previous phase in the group
+	DependsOn []string `json:"dependsOn,omitempty"`
 }
@@ -14,6 +14,8 @@ func (in *ApplyConfig) DeepCopyInto(out *ApplyConfig) {
 	*out = *in
 	out.WaitOptions = in.WaitOptions
 	out.PruneOptions = in.PruneOptions
+	out.ServerSideOptions = in.ServerSideOptions
+	in.InventoryOptions.DeepCopyInto(&out.InventoryOptions)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyConfig.
@@ -26,6 +28,33 @@ func (in *ApplyConfig) DeepCopy() *ApplyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyInventoryOptions) DeepCopyInto(out *ApplyInventoryOptions) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AdoptFrom != nil {
+		in, out := &in.AdoptFrom, &out.AdoptFrom
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyInventoryOptions.
+func (in *ApplyInventoryOptions) DeepCopy() *ApplyInventoryOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyInventoryOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplyPruneOptions) DeepCopyInto(out *ApplyPruneOptions) {
 	*out = *in
@@ -41,6 +70,21 @@ func (in *ApplyPruneOptions) DeepCopy() *ApplyPruneOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyServerSideOptions) DeepCopyInto(out *ApplyServerSideOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyServerSideOptions.
+func (in *ApplyServerSideOptions) DeepCopy() *ApplyServerSideOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyServerSideOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplyWaitOptions) DeepCopyInto(out *ApplyWaitOptions) {
 	*out = *in
@@ -56,6 +100,83 @@ func (in *ApplyWaitOptions) DeepCopy() *ApplyWaitOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaremetalHostSelector) DeepCopyInto(out *BaremetalHostSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaremetalHostSelector.
+func (in *BaremetalHostSelector) DeepCopy() *BaremetalHostSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(BaremetalHostSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaremetalManager) DeepCopyInto(out *BaremetalManager) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaremetalManager.
+func (in *BaremetalManager) DeepCopy() *BaremetalManager {
+	if in == nil {
+		return nil
+	}
+	out := new(BaremetalManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BaremetalManager) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaremetalManagerSpec) DeepCopyInto(out *BaremetalManagerSpec) {
+	*out = *in
+	out.HostSelector = in.HostSelector
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]BaremetalManagerStep, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaremetalManagerSpec.
+func (in *BaremetalManagerSpec) DeepCopy() *BaremetalManagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BaremetalManagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaremetalManagerStep) DeepCopyInto(out *BaremetalManagerStep) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaremetalManagerStep.
+func (in *BaremetalManagerStep) DeepCopy() *BaremetalManagerStep {
+	if in == nil {
+		return nil
+	}
+	out := new(BaremetalManagerStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Builder) DeepCopyInto(out *Builder) {
 	*out = *in
@@ -194,6 +315,79 @@ func (in *Container) DeepCopy() *Container {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericContainer) DeepCopyInto(out *GenericContainer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Output.DeepCopyInto(&out.Output)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericContainer.
+func (in *GenericContainer) DeepCopy() *GenericContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GenericContainer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericContainerOutput) DeepCopyInto(out *GenericContainerOutput) {
+	*out = *in
+	in.Apply.DeepCopyInto(&out.Apply)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericContainerOutput.
+func (in *GenericContainerOutput) DeepCopy() *GenericContainerOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericContainerOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericContainerSpec) DeepCopyInto(out *GenericContainerSpec) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Mounts != nil {
+		in, out := &in.Mounts, &out.Mounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnvVars != nil {
+		in, out := &in.EnvVars, &out.EnvVars
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericContainerSpec.
+func (in *GenericContainerSpec) DeepCopy() *GenericContainerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericContainerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageConfiguration) DeepCopyInto(out *ImageConfiguration) {
 	*out = *in
@@ -295,7 +489,7 @@ func (in *KubernetesApply) DeepCopyInto(out *KubernetesApply) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Config = in.Config
+	in.Config.DeepCopyInto(&out.Config)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesApply.
@@ -316,6 +510,32 @@ func (in *KubernetesApply) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesWait) DeepCopyInto(out *KubernetesWait) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Config.DeepCopyInto(&out.Config)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesWait.
+func (in *KubernetesWait) DeepCopy() *KubernetesWait {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesWait)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubernetesWait) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MoveOptions) DeepCopyInto(out *MoveOptions) {
 	*out = *in
@@ -365,6 +585,25 @@ func (in *PhaseConfig) DeepCopyInto(out *PhaseConfig) {
 		*out = new(v1.ObjectReference)
 		**out = **in
 	}
+	if in.PreRun != nil {
+		in, out := &in.PreRun, &out.PreRun
+		*out = make([]PhaseHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostRun != nil {
+		in, out := &in.PostRun, &out.PostRun
+		*out = make([]PhaseHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(PhaseRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseConfig.
@@ -377,13 +616,35 @@ func (in *PhaseConfig) DeepCopy() *PhaseConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseHook) DeepCopyInto(out *PhaseHook) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseHook.
+func (in *PhaseHook) DeepCopy() *PhaseHook {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PhaseGroup) DeepCopyInto(out *PhaseGroup) {
 	*out = *in
 	if in.Phases != nil {
 		in, out := &in.Phases, &out.Phases
 		*out = make([]PhaseGroupStep, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -400,6 +661,11 @@ func (in *PhaseGroup) DeepCopy() *PhaseGroup {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PhaseGroupStep) DeepCopyInto(out *PhaseGroupStep) {
 	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseGroupStep.
@@ -444,6 +710,26 @@ func (in *PhasePlan) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseRetryPolicy) DeepCopyInto(out *PhaseRetryPolicy) {
+	*out = *in
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]PhaseRetryErrorType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseRetryPolicy.
+func (in *PhaseRetryPolicy) DeepCopy() *PhaseRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Provider) DeepCopyInto(out *Provider) {
 	*out = *in
@@ -490,3 +776,43 @@ func (in *RemoteDirectConfiguration) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WaitConfig) DeepCopyInto(out *WaitConfig) {
+	*out = *in
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]WaitSelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]v1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WaitConfig.
+func (in *WaitConfig) DeepCopy() *WaitConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WaitConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WaitSelector) DeepCopyInto(out *WaitSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WaitSelector.
+func (in *WaitSelector) DeepCopy() *WaitSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(WaitSelector)
+	in.DeepCopyInto(out)
+	return out
+}
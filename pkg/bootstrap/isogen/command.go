@@ -105,7 +105,7 @@ func verifyInputs(cfg *v1alpha1.ImageConfiguration) error {
 	switch {
 	case len(vols) == 1:
 		cfg.Container.Volume = fmt.Sprintf("%s:%s", vols[0], vols[0])
-	case len(vols) > 2:
+	case len(vols) > 2 || vols[0] == "" || vols[1] == "":
 		return config.ErrInvalidConfig{
 			What: "Bad container volume format. Use hostPath:contPath",
 		}
@@ -16,14 +16,16 @@ package isogen
 
 import (
 	"context"
+	"fmt"
 	"io"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/bootstrap/cloudinit"
+	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/container"
 	"opendev.org/airship/airshipctl/pkg/document"
-	"opendev.org/airship/airshipctl/pkg/errors"
 	"opendev.org/airship/airshipctl/pkg/events"
 	"opendev.org/airship/airshipctl/pkg/log"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
@@ -74,8 +76,8 @@ func NewExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
 	}, nil
 }
 
-// Run isogen as a phase runner
-func (c *Executor) Run(evtCh chan events.Event, opts ifc.RunOptions) {
+// Run isogen as a phase runner, builder container is removed if the context is cancelled
+func (c *Executor) Run(ctx context.Context, evtCh chan events.Event, opts ifc.RunOptions) {
 	defer close(evtCh)
 
 	if c.ExecutorBundle == nil {
@@ -103,7 +105,6 @@ func (c *Executor) Run(evtCh chan events.Event, opts ifc.RunOptions) {
 	}
 
 	if c.builder == nil {
-		ctx := context.Background()
 		builder, err := container.NewContainer(
 			&ctx,
 			c.imgConf.Container.ContainerRuntime,
@@ -117,6 +118,12 @@ func (c *Executor) Run(evtCh chan events.Event, opts ifc.RunOptions) {
 
 	err := createBootstrapIso(c.ExecutorBundle, c.builder, c.ExecutorDocument, c.imgConf, log.DebugEnabled())
 	if err != nil {
+		if ctx.Err() != nil && c.builder.GetID() != "" {
+			log.Printf("ISO generation is cancelled, removing container %s", c.builder.GetID())
+			if rmErr := c.builder.RmContainer(); rmErr != nil {
+				log.Printf("Failed to remove container %s: %v", c.builder.GetID(), rmErr)
+			}
+		}
 		handleError(evtCh, err)
 		return
 	}
@@ -145,14 +152,68 @@ func (c *Executor) Run(evtCh chan events.Event, opts ifc.RunOptions) {
 
 // Validate executor configuration and documents
 func (c *Executor) Validate() error {
-	return errors.ErrNotImplemented{}
+	if c.ExecutorBundle == nil {
+		return ErrIsoGenNilBundle{}
+	}
+	if c.imgConf.Container.Image == "" {
+		return config.ErrMissingConfig{What: "Must specify image for ISO builder container"}
+	}
+	// verifyInputs normalizes volume format, so make sure that executor config is not changed
+	return verifyInputs(c.imgConf.DeepCopy())
 }
 
-// Render executor documents
+// Details returns summary of the ISO generation
+func (c *Executor) Details() (string, error) {
+	return fmt.Sprintf("generates ISO with %s image using %s runtime and %s volume",
+		c.imgConf.Container.Image, c.imgConf.Container.ContainerRuntime, c.imgConf.Container.Volume), nil
+}
+
+// Render writes the files passed to the ISO builder container: user-data, network-config
+// and builder config. Every file is rendered as a separate YAML document preceded
+// by a comment with the file name, builder container is not started
 func (c *Executor) Render(w io.Writer, _ ifc.RenderOptions) error {
-	// will be implemented later
-	_, err := w.Write([]byte{})
-	return err
+	if c.ExecutorBundle == nil {
+		return ErrIsoGenNilBundle{}
+	}
+
+	userData, netConf, err := cloudinit.GetCloudData(c.ExecutorBundle)
+	if err != nil {
+		return err
+	}
+
+	builderCfgYaml, err := c.ExecutorDocument.AsYAML()
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{name: c.imgConf.Builder.UserDataFileName, data: userData},
+		{name: c.imgConf.Builder.NetworkConfigFileName, data: netConf},
+		{name: builderConfigFileName, data: builderCfgYaml},
+	}
+	for _, f := range files {
+		if err = renderFile(w, f.name, f.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderFile(w io.Writer, name string, data []byte) error {
+	if _, err := fmt.Fprintf(w, "---\n# %s\n", name); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		_, err := w.Write([]byte("\n"))
+		return err
+	}
+	return nil
 }
 
 func handleError(ch chan<- events.Event, err error) {
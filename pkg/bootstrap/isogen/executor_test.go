@@ -15,6 +15,8 @@
 package isogen
 
 import (
+	"bytes"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/container"
 	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/events"
@@ -95,9 +98,12 @@ func TestExecutorRun(t *testing.T) {
 		MockAsYAML: func() ([]byte, error) { return []byte("TESTDOC"), nil },
 	}
 
+	removed := false
 	testCases := []struct {
 		name        string
 		builder     *mockContainer
+		cancelled   bool
+		removed     bool
 		expectedEvt []events.Event
 	}{
 		{
@@ -148,6 +154,28 @@ func TestExecutorRun(t *testing.T) {
 				wrapError(container.ErrRunContainerCommand{Cmd: "super fail"}),
 			},
 		},
+		{
+			name: "Remove container when cancelled",
+			builder: &mockContainer{
+				runCommand: func() error { return context.Canceled },
+				getID:      func() string { return "TESTID" },
+				rmContainer: func() error {
+					removed = true
+					return nil
+				},
+			},
+			cancelled: true,
+			removed:   true,
+			expectedEvt: []events.Event{
+				{
+					Type: events.IsogenType,
+					IsogenEvent: events.IsogenEvent{
+						Operation: events.IsogenStart,
+					},
+				},
+				wrapError(context.Canceled),
+			},
+		},
 	}
 	for _, test := range testCases {
 		tt := test
@@ -158,8 +186,14 @@ func TestExecutorRun(t *testing.T) {
 				imgConf:          testCfg,
 				builder:          tt.builder,
 			}
+			removed = false
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelled {
+				cancel()
+			}
 			ch := make(chan events.Event)
-			go executor.Run(ch, ifc.RunOptions{})
+			go executor.Run(ctx, ch, ifc.RunOptions{})
 			var actualEvt []events.Event
 			for evt := range ch {
 				if evt.Type == events.IsogenType {
@@ -169,6 +203,151 @@ func TestExecutorRun(t *testing.T) {
 				actualEvt = append(actualEvt, evt)
 			}
 			assert.Equal(t, tt.expectedEvt, actualEvt)
+			assert.Equal(t, tt.removed, removed)
+		})
+	}
+}
+
+func TestExecutorValidate(t *testing.T) {
+	bundle, err := document.NewBundleByPath(executorBundlePath)
+	require.NoError(t, err)
+
+	validCfg := func() *v1alpha1.ImageConfiguration {
+		return &v1alpha1.ImageConfiguration{
+			Container: &v1alpha1.Container{
+				Volume:           "/srv/iso",
+				Image:            "quay.io/airshipit/isogen:latest-ubuntu_focal",
+				ContainerRuntime: "docker",
+			},
+			Builder: &v1alpha1.Builder{
+				UserDataFileName:      "user-data",
+				NetworkConfigFileName: "net-conf",
+			},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		bundle      document.Bundle
+		cfgFunc     func(*v1alpha1.ImageConfiguration)
+		expectedErr error
+	}{
+		{
+			name:    "Success",
+			bundle:  bundle,
+			cfgFunc: func(*v1alpha1.ImageConfiguration) {},
+		},
+		{
+			name:        "Error nil bundle",
+			cfgFunc:     func(*v1alpha1.ImageConfiguration) {},
+			expectedErr: ErrIsoGenNilBundle{},
+		},
+		{
+			name:        "Error missing image",
+			bundle:      bundle,
+			cfgFunc:     func(cfg *v1alpha1.ImageConfiguration) { cfg.Container.Image = "" },
+			expectedErr: config.ErrMissingConfig{What: "Must specify image for ISO builder container"},
+		},
+		{
+			name:   "Error empty container path",
+			bundle: bundle,
+			cfgFunc: func(cfg *v1alpha1.ImageConfiguration) {
+				cfg.Container.Volume = "/srv/iso:"
+			},
+			expectedErr: config.ErrInvalidConfig{What: "Bad container volume format. Use hostPath:contPath"},
+		},
+	}
+	for _, test := range testCases {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validCfg()
+			tt.cfgFunc(cfg)
+			executor := &Executor{
+				ExecutorBundle: tt.bundle,
+				imgConf:        cfg,
+			}
+			assert.Equal(t, tt.expectedErr, executor.Validate())
+			// validation must not change executor configuration
+			expectedCfg := validCfg()
+			tt.cfgFunc(expectedCfg)
+			assert.Equal(t, expectedCfg, executor.imgConf)
+		})
+	}
+}
+
+func TestExecutorDetails(t *testing.T) {
+	executor := &Executor{
+		imgConf: &v1alpha1.ImageConfiguration{
+			Container: &v1alpha1.Container{
+				Volume:           "/srv/iso:/config",
+				Image:            "quay.io/airshipit/isogen:latest-ubuntu_focal",
+				ContainerRuntime: "docker",
+			},
+		},
+	}
+	details, err := executor.Details()
+	require.NoError(t, err)
+	assert.Equal(t, "generates ISO with quay.io/airshipit/isogen:latest-ubuntu_focal image "+
+		"using docker runtime and /srv/iso:/config volume", details)
+}
+
+func TestExecutorRender(t *testing.T) {
+	imgConf := &v1alpha1.ImageConfiguration{
+		Container: &v1alpha1.Container{},
+		Builder: &v1alpha1.Builder{
+			UserDataFileName:      "user-data",
+			NetworkConfigFileName: "network-config",
+		},
+	}
+	testDoc := &MockDocument{
+		MockAsYAML: func() ([]byte, error) { return []byte("kind: ImageConfiguration\n"), nil },
+	}
+
+	testCases := []struct {
+		name        string
+		bundlePath  string
+		expectedOut string
+		expectedErr error
+	}{
+		{
+			name:       "render builder files",
+			bundlePath: executorBundlePath,
+			expectedOut: "---\n# user-data\ncloud-init\n" +
+				"---\n# network-config\nnet-config\n" +
+				"---\n# builder-conf.yaml\nkind: ImageConfiguration\n",
+		},
+		{
+			name:        "cloud-init data is missing",
+			bundlePath:  "testdata/missingvoldoc/site/test-site/ephemeral/bootstrap",
+			expectedErr: document.ErrDocNotFound{},
+		},
+		{
+			name:        "nil bundle",
+			expectedErr: ErrIsoGenNilBundle{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &Executor{
+				ExecutorDocument: testDoc,
+				imgConf:          imgConf,
+			}
+			if tt.bundlePath != "" {
+				bundle, err := document.NewBundleByPath(tt.bundlePath)
+				require.NoError(t, err)
+				executor.ExecutorBundle = bundle
+			}
+
+			out := &bytes.Buffer{}
+			err := executor.Render(out, ifc.RenderOptions{})
+			if tt.expectedErr != nil {
+				assert.IsType(t, tt.expectedErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOut, out.String())
 		})
 	}
 }
@@ -16,6 +16,7 @@ package clustermap
 
 import (
 	"fmt"
+	"strings"
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 )
@@ -39,3 +40,22 @@ type ErrClusterNotInMap struct {
 func (e ErrClusterNotInMap) Error() string {
 	return fmt.Sprintf("cluster %s is not defined in in cluster map %v", e.Child, e.Map)
 }
+
+// ErrUnknownParentCluster returned when parent of the cluster is not defined in cluster map
+type ErrUnknownParentCluster struct {
+	Child  string
+	Parent string
+}
+
+func (e ErrUnknownParentCluster) Error() string {
+	return fmt.Sprintf("parent cluster %s of cluster %s is not defined in cluster map", e.Parent, e.Child)
+}
+
+// ErrClusterCycle returned when clusters in cluster map are parents of each other
+type ErrClusterCycle struct {
+	Clusters []string
+}
+
+func (e ErrClusterCycle) Error() string {
+	return fmt.Sprintf("clusters form a cycle in cluster map: %s", strings.Join(e.Clusters, " -> "))
+}
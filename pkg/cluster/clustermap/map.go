@@ -15,18 +15,27 @@
 package clustermap
 
 import (
+	"fmt"
+	"sort"
+
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/log"
 )
 
+// DefaultClusterNamespace is used if namespace is not defined for the cluster
+const DefaultClusterNamespace = "default"
+
 // ClusterMap interface that allows to list all clusters, find its parent, namespace,
-// check if dynamic kubeconfig is enabled.
+// kubeconfig context and secret, check if dynamic kubeconfig is enabled.
 // TODO use typed cluster names
 type ClusterMap interface {
 	ParentCluster(string) (string, error)
 	AllClusters() []string
 	DynamicKubeConfig(string) bool
 	ClusterNamespace(string) (string, error)
+	ClusterKubeconfigContext(string) (string, error)
+	ClusterKubeconfigSecretName(string) (string, error)
+	Validate() error
 }
 
 // clusterMap allows to view clusters and relationship between them
@@ -73,7 +82,74 @@ func (cm clusterMap) AllClusters() []string {
 }
 
 // ClusterNamespace a namespace for given cluster
-// TODO implement how to get namespace for cluster
 func (cm clusterMap) ClusterNamespace(clusterName string) (string, error) {
-	return "default", nil
+	cluster, exists := cm.apiMap.Map[clusterName]
+	if !exists {
+		return "", ErrClusterNotInMap{Child: clusterName, Map: cm.apiMap}
+	}
+	if cluster.Namespace == "" {
+		return DefaultClusterNamespace, nil
+	}
+	return cluster.Namespace, nil
+}
+
+// ClusterKubeconfigContext returns a name of the kubeconfig context for given cluster
+func (cm clusterMap) ClusterKubeconfigContext(clusterName string) (string, error) {
+	cluster, exists := cm.apiMap.Map[clusterName]
+	if !exists {
+		return "", ErrClusterNotInMap{Child: clusterName, Map: cm.apiMap}
+	}
+	if cluster.KubeconfigContext == "" {
+		return clusterName, nil
+	}
+	return cluster.KubeconfigContext, nil
+}
+
+// ClusterKubeconfigSecretName returns a name of the secret with kubeconfig for given cluster
+func (cm clusterMap) ClusterKubeconfigSecretName(clusterName string) (string, error) {
+	cluster, exists := cm.apiMap.Map[clusterName]
+	if !exists {
+		return "", ErrClusterNotInMap{Child: clusterName, Map: cm.apiMap}
+	}
+	if cluster.KubeconfigSecretName == "" {
+		return fmt.Sprintf("%s-kubeconfig", clusterName), nil
+	}
+	return cluster.KubeconfigSecretName, nil
+}
+
+// Validate makes sure that parents of all clusters are defined in the map
+// and clusters don't form a cycle
+func (cm clusterMap) Validate() error {
+	parentOf := func(name string) string {
+		if cluster := cm.apiMap.Map[name]; cluster != nil {
+			return cluster.Parent
+		}
+		return ""
+	}
+
+	names := cm.AllClusters()
+	sort.Strings(names)
+	for _, name := range names {
+		parent := parentOf(name)
+		if parent == "" {
+			continue
+		}
+		if _, exists := cm.apiMap.Map[parent]; !exists {
+			return ErrUnknownParentCluster{Child: name, Parent: parent}
+		}
+	}
+
+	// walk up from every cluster to the top level one, all parents are known at this point
+	for _, name := range names {
+		path := []string{name}
+		for parent := parentOf(name); parent != ""; parent = parentOf(parent) {
+			for i, visited := range path {
+				if visited == parent {
+					return ErrClusterCycle{Clusters: append(path[i:], parent)}
+				}
+			}
+			path = append(path, parent)
+		}
+	}
+	return nil
 }
@@ -32,8 +32,11 @@ func TestClusterMap(t *testing.T) {
 	apiMap := &v1alpha1.ClusterMap{
 		Map: map[string]*v1alpha1.Cluster{
 			targetCluster: {
-				Parent:            ephemeraCluster,
-				DynamicKubeConfig: false,
+				Parent:               ephemeraCluster,
+				DynamicKubeConfig:    false,
+				Namespace:            "target-infra",
+				KubeconfigContext:    "target-context",
+				KubeconfigSecretName: "target-secret",
 			},
 			ephemeraCluster: {},
 			workloadCluster: {
@@ -87,4 +90,92 @@ func TestClusterMap(t *testing.T) {
 		clusters := cMap.AllClusters()
 		assert.Len(t, clusters, 4)
 	})
+
+	t.Run("cluster namespace", func(t *testing.T) {
+		ns, err := cMap.ClusterNamespace(targetCluster)
+		assert.NoError(t, err)
+		assert.Equal(t, "target-infra", ns)
+	})
+
+	t.Run("default cluster namespace", func(t *testing.T) {
+		ns, err := cMap.ClusterNamespace(workloadCluster)
+		assert.NoError(t, err)
+		assert.Equal(t, clustermap.DefaultClusterNamespace, ns)
+	})
+
+	t.Run("cluster kubeconfig context", func(t *testing.T) {
+		context, err := cMap.ClusterKubeconfigContext(targetCluster)
+		assert.NoError(t, err)
+		assert.Equal(t, "target-context", context)
+	})
+
+	t.Run("default cluster kubeconfig context", func(t *testing.T) {
+		context, err := cMap.ClusterKubeconfigContext(ephemeraCluster)
+		assert.NoError(t, err)
+		assert.Equal(t, ephemeraCluster, context)
+	})
+
+	t.Run("cluster kubeconfig secret", func(t *testing.T) {
+		secret, err := cMap.ClusterKubeconfigSecretName(targetCluster)
+		assert.NoError(t, err)
+		assert.Equal(t, "target-secret", secret)
+	})
+
+	t.Run("default cluster kubeconfig secret", func(t *testing.T) {
+		secret, err := cMap.ClusterKubeconfigSecretName(workloadCluster)
+		assert.NoError(t, err)
+		assert.Equal(t, "workload-kubeconfig", secret)
+	})
+
+	t.Run("unknown cluster", func(t *testing.T) {
+		_, err := cMap.ClusterNamespace("does not exist")
+		assert.Error(t, err)
+		_, err = cMap.ClusterKubeconfigContext("does not exist")
+		assert.Error(t, err)
+		_, err = cMap.ClusterKubeconfigSecretName("does not exist")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid map", func(t *testing.T) {
+		assert.NoError(t, cMap.Validate())
+	})
+}
+
+func TestClusterMapValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		apiMap      map[string]*v1alpha1.Cluster
+		expectedErr error
+	}{
+		{
+			name: "unknown parent",
+			apiMap: map[string]*v1alpha1.Cluster{
+				"target": {Parent: "ephemeral"},
+			},
+			expectedErr: clustermap.ErrUnknownParentCluster{Child: "target", Parent: "ephemeral"},
+		},
+		{
+			name: "cycle",
+			apiMap: map[string]*v1alpha1.Cluster{
+				"ephemeral": {},
+				"target":    {Parent: "workload"},
+				"workload":  {Parent: "target"},
+			},
+			expectedErr: clustermap.ErrClusterCycle{Clusters: []string{"target", "workload", "target"}},
+		},
+		{
+			name: "cluster is a parent of itself",
+			apiMap: map[string]*v1alpha1.Cluster{
+				"target": {Parent: "target"},
+			},
+			expectedErr: clustermap.ErrClusterCycle{Clusters: []string{"target", "target"}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cMap := clustermap.NewClusterMap(&v1alpha1.ClusterMap{Map: tt.apiMap})
+			assert.Equal(t, tt.expectedErr, cMap.Validate())
+		})
+	}
 }
@@ -0,0 +1,70 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package graph
+
+import (
+	"io"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/phase"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+// ListFlags options for cluster list command
+type ListFlags struct {
+	// Output is an output format, one of tree, dot or json
+	Output string
+}
+
+// ListCommand cluster list command
+type ListCommand struct {
+	Options ListFlags
+	Factory config.Factory
+	Writer  io.Writer
+}
+
+// RunE prints clusters defined in cluster map along with the phases executed against them
+func (c *ListCommand) RunE() error {
+	cfg, err := c.Factory()
+	if err != nil {
+		return err
+	}
+
+	helper, err := phase.NewHelper(cfg)
+	if err != nil {
+		return err
+	}
+
+	cMap, err := helper.ClusterMap()
+	if err != nil {
+		return err
+	}
+
+	phases, err := helper.ListPhases(ifc.ListPhaseOptions{})
+	if err != nil {
+		return err
+	}
+
+	// kubeconfig is built the same way it is done for phases
+	source := func(clusterName string) string {
+		return kubeconfig.NewBuilder().
+			WithBundle(helper.PhaseRoot()).
+			WithClusterMap(cMap).
+			WithClusterName(clusterName).
+			Source()
+	}
+	return Print(New(cMap, phases, source), c.Options.Output, c.Writer)
+}
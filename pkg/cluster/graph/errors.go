@@ -0,0 +1,27 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package graph
+
+import "fmt"
+
+// ErrInvalidOutputFormat returned when unsupported output format is requested
+type ErrInvalidOutputFormat struct {
+	RequestedFormat string
+}
+
+func (e ErrInvalidOutputFormat) Error() string {
+	return fmt.Sprintf("invalid output format %s, must be one of: %s, %s, %s",
+		e.RequestedFormat, TreeOutputFormat, DOTOutputFormat, JSONOutputFormat)
+}
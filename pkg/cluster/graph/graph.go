@@ -0,0 +1,170 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
+	"opendev.org/airship/airshipctl/pkg/util"
+)
+
+// Supported output formats of cluster list
+const (
+	TreeOutputFormat = "tree"
+	DOTOutputFormat  = "dot"
+	JSONOutputFormat = "json"
+)
+
+// Cluster is a node of the cluster graph built from cluster map
+type Cluster struct {
+	Name              string     `json:"name"`
+	Parent            string     `json:"parent,omitempty"`
+	DynamicKubeconfig bool       `json:"dynamicKubeconfig"`
+	KubeconfigSource  string     `json:"kubeconfigSource"`
+	Phases            []string   `json:"phases"`
+	Children          []*Cluster `json:"children,omitempty"`
+}
+
+// SourceFunc returns a human readable description of the kubeconfig source of the cluster
+type SourceFunc func(clusterName string) string
+
+// New builds a graph of the clusters defined in cluster map and returns top level clusters,
+// i.e. clusters without parent. Phases are assigned to the clusters they are executed against
+func New(cMap clustermap.ClusterMap, phases []*v1alpha1.Phase, source SourceFunc) []*Cluster {
+	names := cMap.AllClusters()
+	sort.Strings(names)
+
+	clusters := make(map[string]*Cluster, len(names))
+	for _, name := range names {
+		clusters[name] = &Cluster{
+			Name:              name,
+			DynamicKubeconfig: cMap.DynamicKubeConfig(name),
+			KubeconfigSource:  source(name),
+			Phases:            []string{},
+		}
+	}
+	for _, p := range phases {
+		if c, exists := clusters[p.ClusterName]; exists {
+			c.Phases = append(c.Phases, p.Name)
+		}
+	}
+
+	roots := []*Cluster{}
+	for _, name := range names {
+		c := clusters[name]
+		parent, err := cMap.ParentCluster(name)
+		if _, exists := clusters[parent]; err != nil || !exists {
+			roots = append(roots, c)
+			continue
+		}
+		c.Parent = parent
+		clusters[parent].Children = append(clusters[parent].Children, c)
+	}
+	return roots
+}
+
+// Print writes cluster graph to the writer in requested format
+func Print(roots []*Cluster, format string, w io.Writer) error {
+	switch format {
+	case TreeOutputFormat:
+		return PrintTree(roots, w)
+	case DOTOutputFormat:
+		return PrintDOT(roots, w)
+	case JSONOutputFormat:
+		return PrintJSON(roots, w)
+	default:
+		return ErrInvalidOutputFormat{RequestedFormat: format}
+	}
+}
+
+// PrintTree prints clusters as a table, where cluster names are indented according to their place in the tree
+func PrintTree(roots []*Cluster, w io.Writer) error {
+	tw := util.NewTabWriter(w)
+	defer tw.Flush()
+	fmt.Fprintf(tw, "CLUSTER\tDYNAMIC KUBECONFIG\tKUBECONFIG SOURCE\tPHASES\n")
+
+	var printCluster func(c *Cluster, prefix, branch string)
+	printCluster = func(c *Cluster, prefix, branch string) {
+		fmt.Fprintf(tw, "%s%s%s\t%t\t%s\t%s\n",
+			prefix, branch, c.Name, c.DynamicKubeconfig, c.KubeconfigSource, strings.Join(c.Phases, ","))
+		// children are indented under the name of the parent
+		switch branch {
+		case "├── ":
+			prefix += "│   "
+		case "└── ":
+			prefix += "    "
+		}
+		for i, child := range c.Children {
+			if i == len(c.Children)-1 {
+				printCluster(child, prefix, "└── ")
+			} else {
+				printCluster(child, prefix, "├── ")
+			}
+		}
+	}
+	for _, root := range roots {
+		printCluster(root, "", "")
+	}
+	return nil
+}
+
+// PrintDOT prints cluster graph in Graphviz DOT format, edges are directed from parent to child clusters
+// and edges of the clusters with dynamic kubeconfig are dashed
+func PrintDOT(roots []*Cluster, w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "digraph clusters {"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "  node [shape=box];"); err != nil {
+		return err
+	}
+
+	var printCluster func(c *Cluster)
+	printCluster = func(c *Cluster) {
+		label := c.Name
+		if len(c.Phases) > 0 {
+			label = fmt.Sprintf("%s\nphases: %s", c.Name, strings.Join(c.Phases, ", "))
+		}
+		fmt.Fprintf(w, "  %q [label=%q];\n", c.Name, label)
+		for _, child := range c.Children {
+			style := "solid"
+			if child.DynamicKubeconfig {
+				style = "dashed"
+			}
+			fmt.Fprintf(w, "  %q -> %q [style=%s];\n", c.Name, child.Name, style)
+			printCluster(child)
+		}
+	}
+	for _, root := range roots {
+		printCluster(root)
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+// PrintJSON prints cluster graph in JSON format, children are nested into their parents
+func PrintJSON(roots []*Cluster, w io.Writer) error {
+	out, err := json.MarshalIndent(roots, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(out))
+	return err
+}
@@ -0,0 +1,132 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package graph_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
+	"opendev.org/airship/airshipctl/pkg/cluster/graph"
+)
+
+func testGraph() []*graph.Cluster {
+	cMap := clustermap.NewClusterMap(&v1alpha1.ClusterMap{
+		Map: map[string]*v1alpha1.Cluster{
+			"ephemeral": {},
+			"target":    {Parent: "ephemeral"},
+			"workload1": {Parent: "target", DynamicKubeConfig: true},
+			"workload2": {Parent: "target", DynamicKubeConfig: true},
+		},
+	})
+	phases := []*v1alpha1.Phase{
+		{ObjectMeta: metav1.ObjectMeta{Name: "initinfra"}, ClusterName: "ephemeral"},
+		{ObjectMeta: metav1.ObjectMeta{Name: "controlplane"}, ClusterName: "ephemeral"},
+		{ObjectMeta: metav1.ObjectMeta{Name: "workers"}, ClusterName: "target"},
+		{ObjectMeta: metav1.ObjectMeta{Name: "unknown"}, ClusterName: "unknown"},
+	}
+	source := func(clusterName string) string {
+		return "source-" + clusterName
+	}
+	return graph.New(cMap, phases, source)
+}
+
+func TestNew(t *testing.T) {
+	roots := testGraph()
+	require.Len(t, roots, 1)
+
+	ephemeral := roots[0]
+	assert.Equal(t, "ephemeral", ephemeral.Name)
+	assert.Equal(t, "", ephemeral.Parent)
+	assert.Equal(t, []string{"initinfra", "controlplane"}, ephemeral.Phases)
+	assert.Equal(t, "source-ephemeral", ephemeral.KubeconfigSource)
+	require.Len(t, ephemeral.Children, 1)
+
+	target := ephemeral.Children[0]
+	assert.Equal(t, "target", target.Name)
+	assert.Equal(t, "ephemeral", target.Parent)
+	assert.Equal(t, []string{"workers"}, target.Phases)
+	require.Len(t, target.Children, 2)
+	assert.Equal(t, "workload1", target.Children[0].Name)
+	assert.Equal(t, "workload2", target.Children[1].Name)
+	assert.True(t, target.Children[0].DynamicKubeconfig)
+	assert.Empty(t, target.Children[0].Phases)
+}
+
+func TestPrint(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:   "tree",
+			format: graph.TreeOutputFormat,
+			expected: "CLUSTER             DYNAMIC KUBECONFIG   KUBECONFIG SOURCE   PHASES\n" +
+				"ephemeral           false                source-ephemeral    initinfra,controlplane\n" +
+				"└── target          false                source-target       workers\n" +
+				"    ├── workload1   true                 source-workload1    \n" +
+				"    └── workload2   true                 source-workload2    \n",
+		},
+		{
+			name:   "dot",
+			format: graph.DOTOutputFormat,
+			expected: `digraph clusters {
+  node [shape=box];
+  "ephemeral" [label="ephemeral\nphases: initinfra, controlplane"];
+  "ephemeral" -> "target" [style=solid];
+  "target" [label="target\nphases: workers"];
+  "target" -> "workload1" [style=dashed];
+  "workload1" [label="workload1"];
+  "target" -> "workload2" [style=dashed];
+  "workload2" [label="workload2"];
+}
+`,
+		},
+		{
+			name:        "invalid format",
+			format:      "yaml",
+			expectedErr: graph.ErrInvalidOutputFormat{RequestedFormat: "yaml"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := graph.Print(testGraph(), tt.format, buf)
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestPrintJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, graph.PrintJSON(testGraph(), buf))
+	assert.Contains(t, buf.String(), `"name": "ephemeral"`)
+	assert.Contains(t, buf.String(), `"parent": "target"`)
+	assert.Contains(t, buf.String(), `"dynamicKubeconfig": true`)
+	assert.Contains(t, buf.String(), `"kubeconfigSource": "source-workload2"`)
+}
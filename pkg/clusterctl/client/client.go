@@ -15,9 +15,17 @@
 package client
 
 import (
+	"bytes"
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	clusterctlclient "sigs.k8s.io/cluster-api/cmd/clusterctl/client"
 	clusterctlconfig "sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
 	clog "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	"sigs.k8s.io/yaml"
 
 	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/clusterctl/implementations"
@@ -29,12 +37,17 @@ var _ Interface = &Client{}
 // Interface is abstraction to Clusterctl
 type Interface interface {
 	Init(kubeconfigPath, kubeconfigContext string) error
-	Move(fromKubeconfigPath, fromKubeconfigContext, toKubeconfigPath, toKubeconfigContext, namespace string) error
+	Move(ctx context.Context,
+		fromKubeconfigPath, fromKubeconfigContext, toKubeconfigPath, toKubeconfigContext, namespace string) error
+	// ProviderComponents returns documents of the providers requested by init options
+	ProviderComponents() ([]byte, error)
 }
 
 // Client Implements interface to Clusterctl
 type Client struct {
 	clusterctlClient clusterctlclient.Client
+	configClient     clusterctlconfig.Client
+	repoFactory      RepositoryFactory
 	initOptions      clusterctlclient.InitOptions
 	moveOptions      clusterctlclient.MoveOptions
 }
@@ -55,11 +68,19 @@ func NewClient(root string, debug bool, options *airshipv1.Clusterctl) (Interfac
 			ControlPlaneProviders:   initOptions.ControlPlaneProviders,
 		}
 	}
-	cclient, err := newClusterctlClient(root, options)
+	cconf, err := newConfig(options, root)
+	if err != nil {
+		return nil, err
+	}
+	rf := RepositoryFactory{
+		Options:      options,
+		ConfigClient: cconf,
+	}
+	cclient, err := newClusterctlClient(cconf, rf)
 	if err != nil {
 		return nil, err
 	}
-	return &Client{clusterctlClient: cclient, initOptions: cio}, nil
+	return &Client{clusterctlClient: cclient, configClient: cconf, repoFactory: rf, initOptions: cio}, nil
 }
 
 // Init implements interface to Clusterctl
@@ -91,15 +112,7 @@ func newConfig(options *airshipv1.Clusterctl, root string) (clusterctlconfig.Cli
 	return clusterctlconfig.New("", clusterctlconfig.InjectReader(reader))
 }
 
-func newClusterctlClient(root string, options *airshipv1.Clusterctl) (clusterctlclient.Client, error) {
-	cconf, err := newConfig(options, root)
-	if err != nil {
-		return nil, err
-	}
-	rf := RepositoryFactory{
-		Options:      options,
-		ConfigClient: cconf,
-	}
+func newClusterctlClient(cconf clusterctlconfig.Client, rf RepositoryFactory) (clusterctlclient.Client, error) {
 	// option config factory
 	ocf := clusterctlclient.InjectConfig(cconf)
 	// option repository factory
@@ -108,3 +121,98 @@ func newClusterctlClient(root string, options *airshipv1.Clusterctl) (clusterctl
 	occf := clusterctlclient.InjectClusterClientFactory(rf.ClusterClientFactory())
 	return clusterctlclient.New("", ocf, orf, occf)
 }
+
+// ProviderComponents returns components of the providers requested by init options as a multi-document
+// YAML, variables are substituted the same way as during init. Empty core provider isn't rendered, since
+// it is resolved by init only. ErrUnresolvedVariables is returned if components contain variables without values
+func (c *Client) ProviderComponents() ([]byte, error) {
+	requested := []struct {
+		providerType clusterctlv1.ProviderType
+		providers    []string
+	}{
+		{clusterctlv1.CoreProviderType, []string{c.initOptions.CoreProvider}},
+		{clusterctlv1.BootstrapProviderType, c.initOptions.BootstrapProviders},
+		{clusterctlv1.ControlPlaneProviderType, c.initOptions.ControlPlaneProviders},
+		{clusterctlv1.InfrastructureProviderType, c.initOptions.InfrastructureProviders},
+	}
+	configProviders, err := c.configClient.Providers().List()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	for _, r := range requested {
+		for _, provider := range r.providers {
+			if provider == "" {
+				continue
+			}
+			components, compErr := c.components(configProviders, provider, r.providerType)
+			if compErr != nil {
+				return nil, compErr
+			}
+			// shared objects such as CRDs are installed first
+			if err = writeObjects(buf, components.SharedObjs()); err != nil {
+				return nil, err
+			}
+			if err = writeObjects(buf, components.InstanceObjs()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func writeObjects(buf *bytes.Buffer, objs []unstructured.Unstructured) error {
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		buf.WriteString("---\n")
+		buf.Write(data)
+	}
+	return nil
+}
+
+// components reads components of the provider in the name:version format from its repository
+func (c *Client) components(
+	configProviders []clusterctlconfig.Provider,
+	provider string,
+	providerType clusterctlv1.ProviderType) (repository.Components, error) {
+	parts := strings.SplitN(provider, ":", 2)
+	name, version := parts[0], ""
+	if len(parts) == 2 {
+		version = parts[1]
+	}
+
+	var configProvider clusterctlconfig.Provider
+	for _, p := range configProviders {
+		if p.Name() == name && p.Type() == providerType {
+			configProvider = p
+		}
+	}
+	if configProvider == nil {
+		return nil, ErrProviderRepoNotFound{ProviderName: name, ProviderType: string(providerType)}
+	}
+
+	repoClient, err := c.repoFactory.repoFactory(configProvider)
+	if err != nil {
+		return nil, err
+	}
+	components, err := repoClient.Components().Get(repository.ComponentsOptions{Version: version})
+	if err != nil {
+		return nil, err
+	}
+
+	// variables are left as is if substitution is disabled or they are not set
+	unresolved := []string{}
+	for _, variable := range components.Variables() {
+		if _, varErr := c.configClient.Variables().Get(variable); varErr != nil {
+			unresolved = append(unresolved, variable)
+		}
+	}
+	if len(unresolved) > 0 {
+		return nil, ErrUnresolvedVariables{ProviderName: name, ProviderType: string(providerType), Variables: unresolved}
+	}
+	return components, nil
+}
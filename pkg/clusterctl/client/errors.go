@@ -16,6 +16,7 @@ package client
 
 import (
 	"fmt"
+	"strings"
 )
 
 // ErrProviderNotDefined is returned when wrong AuthType is provided
@@ -46,3 +47,38 @@ type ErrUnknownExecutorAction struct {
 func (e ErrUnknownExecutorAction) Error() string {
 	return fmt.Sprintf("unknown action type '%s'", e.Action)
 }
+
+// ErrProviderVersionNotDefined is returned when provider version requested by init options
+// is not defined in Clusterctl document
+type ErrProviderVersionNotDefined struct {
+	ProviderName string
+	Version      string
+}
+
+func (e ErrProviderVersionNotDefined) Error() string {
+	return fmt.Sprintf("version %s of provider %s is not defined in Clusterctl document", e.Version, e.ProviderName)
+}
+
+// ErrProviderVersionPath is returned when manifests of the provider version are not available
+type ErrProviderVersionPath struct {
+	ProviderName string
+	Version      string
+	Err          error
+}
+
+func (e ErrProviderVersionPath) Error() string {
+	return fmt.Sprintf("manifests for version %s of provider %s are not available: %v",
+		e.Version, e.ProviderName, e.Err)
+}
+
+// ErrUnresolvedVariables is returned when provider components contain variables which values are not set
+type ErrUnresolvedVariables struct {
+	ProviderName string
+	ProviderType string
+	Variables    []string
+}
+
+func (e ErrUnresolvedVariables) Error() string {
+	return fmt.Sprintf("values of variables %s are not set for provider %s of type %s",
+		strings.Join(e.Variables, ", "), e.ProviderName, e.ProviderType)
+}
@@ -15,13 +15,19 @@
 package client
 
 import (
+	"context"
+	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 
 	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
-	"opendev.org/airship/airshipctl/pkg/errors"
+	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/events"
 	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
 	"opendev.org/airship/airshipctl/pkg/log"
@@ -32,7 +38,9 @@ var _ ifc.Executor = &ClusterctlExecutor{}
 
 // ClusterctlExecutor phase executor
 type ClusterctlExecutor struct {
-	clusterName string
+	clusterName       string
+	kubeconfigContext string
+	targetPath        string
 
 	Interface
 	clusterMap clustermap.ClusterMap
@@ -62,28 +70,30 @@ func NewExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
 		return nil, err
 	}
 	return &ClusterctlExecutor{
-		clusterName: cfg.ClusterName,
-		Interface:   client,
-		options:     options,
-		kubecfg:     cfg.KubeConfig,
-		clusterMap:  cfg.ClusterMap,
+		clusterName:       cfg.ClusterName,
+		kubeconfigContext: cfg.KubeconfigContext,
+		targetPath:        cfg.Helper.TargetPath(),
+		Interface:         client,
+		options:           options,
+		kubecfg:           cfg.KubeConfig,
+		clusterMap:        cfg.ClusterMap,
 	}, nil
 }
 
 // Run clusterctl init as a phase runner
-func (c *ClusterctlExecutor) Run(evtCh chan events.Event, opts ifc.RunOptions) {
+func (c *ClusterctlExecutor) Run(ctx context.Context, evtCh chan events.Event, opts ifc.RunOptions) {
 	defer close(evtCh)
 	switch c.options.Action {
 	case airshipv1.Move:
-		c.move(opts, evtCh)
+		c.move(ctx, opts, evtCh)
 	case airshipv1.Init:
-		c.init(opts, evtCh)
+		c.init(ctx, opts, evtCh)
 	default:
 		c.handleErr(ErrUnknownExecutorAction{Action: string(c.options.Action)}, evtCh)
 	}
 }
 
-func (c *ClusterctlExecutor) move(opts ifc.RunOptions, evtCh chan events.Event) {
+func (c *ClusterctlExecutor) move(ctx context.Context, opts ifc.RunOptions, evtCh chan events.Event) {
 	evtCh <- events.Event{
 		Type: events.ClusterctlType,
 		ClusterctlEvent: events.ClusterctlEvent{
@@ -91,14 +101,13 @@ func (c *ClusterctlExecutor) move(opts ifc.RunOptions, evtCh chan events.Event)
 			Message:   "starting clusterctl move executor",
 		},
 	}
-	ns := c.options.MoveOptions.Namespace
 	kubeConfigFile, cleanup, err := c.kubecfg.GetFile()
 	if err != nil {
 		c.handleErr(err, evtCh)
 		return
 	}
 	defer cleanup()
-	fromContext, err := c.clusterMap.ParentCluster(c.clusterName)
+	fromContext, toContext, ns, err := c.moveTarget()
 	if err != nil {
 		c.handleErr(err, evtCh)
 		return
@@ -107,9 +116,10 @@ func (c *ClusterctlExecutor) move(opts ifc.RunOptions, evtCh chan events.Event)
 	log.Print("command 'clusterctl move' is going to be executed")
 	// TODO (kkalynovskyi) add more details to dry-run, for now if dry run is set we skip move command
 	if !opts.DryRun {
-		err = c.Move(kubeConfigFile, fromContext, kubeConfigFile, c.clusterName, ns)
+		err = c.Move(ctx, kubeConfigFile, fromContext, kubeConfigFile, toContext, ns)
 		if err != nil {
 			c.handleErr(err, evtCh)
+			return
 		}
 	}
 
@@ -122,7 +132,32 @@ func (c *ClusterctlExecutor) move(opts ifc.RunOptions, evtCh chan events.Event)
 	}
 }
 
-func (c *ClusterctlExecutor) init(opts ifc.RunOptions, evtCh chan events.Event) {
+// moveTarget returns kubeconfig contexts of the parent and the phase clusters along with namespace
+// of the objects to move, namespace set in move options takes precedence over the cluster map one
+func (c *ClusterctlExecutor) moveTarget() (string, string, string, error) {
+	parent, err := c.clusterMap.ParentCluster(c.clusterName)
+	if err != nil {
+		return "", "", "", err
+	}
+	fromContext, err := c.clusterMap.ClusterKubeconfigContext(parent)
+	if err != nil {
+		return "", "", "", err
+	}
+	toContext, err := c.clusterMap.ClusterKubeconfigContext(c.clusterName)
+	if err != nil {
+		return "", "", "", err
+	}
+	if c.options.MoveOptions != nil && c.options.MoveOptions.Namespace != "" {
+		return fromContext, toContext, c.options.MoveOptions.Namespace, nil
+	}
+	ns, err := c.clusterMap.ClusterNamespace(c.clusterName)
+	if err != nil {
+		return "", "", "", err
+	}
+	return fromContext, toContext, ns, nil
+}
+
+func (c *ClusterctlExecutor) init(ctx context.Context, opts ifc.RunOptions, evtCh chan events.Event) {
 	evtCh <- events.Event{
 		Type: events.ClusterctlType,
 		ClusterctlEvent: events.ClusterctlEvent{
@@ -150,10 +185,15 @@ func (c *ClusterctlExecutor) init(opts ifc.RunOptions, evtCh chan events.Event)
 		}
 		return
 	}
-	// Use cluster name as context in kubeconfig file
-	err = c.Init(kubeConfigFile, c.clusterName)
+	// clusterctl init can't be interrupted, so make sure the run is not cancelled before it is started
+	if err = ctx.Err(); err != nil {
+		c.handleErr(err, evtCh)
+		return
+	}
+	err = c.Init(kubeConfigFile, c.kubeconfigContext)
 	if err != nil {
 		c.handleErr(err, evtCh)
+		return
 	}
 	evtCh <- events.Event{
 		Type: events.ClusterctlType,
@@ -175,12 +215,128 @@ func (c *ClusterctlExecutor) handleErr(err error, evtCh chan events.Event) {
 
 // Validate executor configuration and documents
 func (c *ClusterctlExecutor) Validate() error {
-	return errors.ErrNotImplemented{}
+	switch c.options.Action {
+	case airshipv1.Move:
+		return nil
+	case airshipv1.Init:
+		return c.validateInit()
+	default:
+		return ErrUnknownExecutorAction{Action: string(c.options.Action)}
+	}
+}
+
+// validateInit makes sure that providers requested by init options are defined
+// in Clusterctl document and their versions are available in the manifests
+func (c *ClusterctlExecutor) validateInit() error {
+	initOptions := c.options.InitOptions
+	if initOptions == nil {
+		return nil
+	}
+	requested := []struct {
+		providerType clusterctlv1.ProviderType
+		providers    []string
+	}{
+		{clusterctlv1.CoreProviderType, []string{initOptions.CoreProvider}},
+		{clusterctlv1.BootstrapProviderType, initOptions.BootstrapProviders},
+		{clusterctlv1.ControlPlaneProviderType, initOptions.ControlPlaneProviders},
+		{clusterctlv1.InfrastructureProviderType, initOptions.InfrastructureProviders},
+	}
+	for _, r := range requested {
+		for _, provider := range r.providers {
+			// empty core provider means that the latest cluster-api release is used
+			if provider == "" {
+				continue
+			}
+			if err := c.validateProvider(provider, r.providerType); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateProvider checks provider in the name:version format used by clusterctl init options
+func (c *ClusterctlExecutor) validateProvider(provider string, providerType clusterctlv1.ProviderType) error {
+	parts := strings.SplitN(provider, ":", 2)
+	name := parts[0]
+	prov := c.options.Provider(name, providerType)
+	if prov == nil {
+		return ErrProviderRepoNotFound{ProviderName: name, ProviderType: string(providerType)}
+	}
+	// versions of clusterctl repositories are resolved remotely
+	if prov.IsClusterctlRepository {
+		return nil
+	}
+	if len(prov.Versions) == 0 {
+		return ErrProviderRepoNotFound{ProviderName: name, ProviderType: string(providerType)}
+	}
+	versions := prov.Versions
+	if len(parts) == 2 {
+		path, found := prov.Versions[parts[1]]
+		if !found {
+			return ErrProviderVersionNotDefined{ProviderName: name, Version: parts[1]}
+		}
+		versions = map[string]string{parts[1]: path}
+	}
+	for version, path := range versions {
+		if _, err := os.Stat(filepath.Join(c.targetPath, path)); err != nil {
+			return ErrProviderVersionPath{ProviderName: name, Version: version, Err: err}
+		}
+	}
+	return nil
+}
+
+// Details returns summary of the clusterctl action
+func (c *ClusterctlExecutor) Details() (string, error) {
+	switch c.options.Action {
+	case airshipv1.Init:
+		providers := []string{}
+		if initOptions := c.options.InitOptions; initOptions != nil {
+			if initOptions.CoreProvider != "" {
+				providers = append(providers, initOptions.CoreProvider)
+			}
+			providers = append(providers, initOptions.BootstrapProviders...)
+			providers = append(providers, initOptions.ControlPlaneProviders...)
+			providers = append(providers, initOptions.InfrastructureProviders...)
+		}
+		if len(providers) == 0 {
+			return fmt.Sprintf("clusterctl init of default providers on %s", c.clusterName), nil
+		}
+		return fmt.Sprintf("clusterctl init with providers %s on %s",
+			strings.Join(providers, ", "), c.clusterName), nil
+	case airshipv1.Move:
+		parent, err := c.clusterMap.ParentCluster(c.clusterName)
+		if err != nil {
+			return "", err
+		}
+		// namespace is resolved the same way as for the actual move
+		_, _, namespace, err := c.moveTarget()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("clusterctl move of namespace %s from %s to %s",
+			namespace, parent, c.clusterName), nil
+	default:
+		return "", ErrUnknownExecutorAction{Action: string(c.options.Action)}
+	}
 }
 
-// Render executor documents
-func (c *ClusterctlExecutor) Render(w io.Writer, _ ifc.RenderOptions) error {
-	// will be implemented later
-	_, err := w.Write([]byte{})
-	return err
+// Render provider components installed by clusterctl init, nothing is rendered for move action
+func (c *ClusterctlExecutor) Render(w io.Writer, o ifc.RenderOptions) error {
+	if c.options.Action != airshipv1.Init {
+		return nil
+	}
+	components, err := c.ProviderComponents()
+	if err != nil {
+		return err
+	}
+	bundle, err := document.NewBundleFromBytes(components)
+	if err != nil {
+		return err
+	}
+	filtered, err := bundle.SelectBundle(o.FilterSelector)
+	if err != nil {
+		return err
+	}
+	return filtered.Write(w)
 }
@@ -16,6 +16,7 @@ package client_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -25,10 +26,11 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
 	cctlclient "opendev.org/airship/airshipctl/pkg/clusterctl/client"
 	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/document"
-	airerrors "opendev.org/airship/airshipctl/pkg/errors"
 	"opendev.org/airship/airshipctl/pkg/events"
 	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
 	"opendev.org/airship/airshipctl/pkg/phase"
@@ -177,7 +179,7 @@ func TestExecutorRun(t *testing.T) {
 				})
 			require.NoError(t, err)
 			ch := make(chan events.Event)
-			go executor.Run(ch, ifc.RunOptions{DryRun: true})
+			go executor.Run(context.Background(), ch, ifc.RunOptions{DryRun: true})
 			var actualEvt []events.Event
 			for evt := range ch {
 				if evt.Type == events.ClusterctlType {
@@ -192,28 +194,246 @@ func TestExecutorRun(t *testing.T) {
 }
 
 func TestExecutorValidate(t *testing.T) {
-	sampleCfgDoc := executorDoc(t, "init")
-	executor, err := cctlclient.NewExecutor(
-		ifc.ExecutorConfig{
-			ExecutorDocument: sampleCfgDoc,
-			Helper:           makeDefaultHelper(t),
+	validateConfigTmpl := `
+apiVersion: airshipit.org/v1alpha1
+kind: Clusterctl
+metadata:
+  name: clusterctl-v1
+action: %s
+init-options:
+  infrastructure-providers:
+    - %s
+providers:
+  - name: "metal3"
+    type: "InfrastructureProvider"
+    versions:
+      v0.3.1: functions/capi/infrastructure/v0.3.1
+      v0.3.3: functions/capi/infrastructure/v0.3.3
+  - name: "aws"
+    type: "InfrastructureProvider"
+    url: "https://github.com/kubernetes-sigs/cluster-api-provider-aws/releases/v0.5.0/infrastructure-components.yaml"
+    clusterctl-repository: true`
+
+	tests := []struct {
+		name        string
+		action      string
+		provider    string
+		expectedErr error
+	}{
+		{
+			name:     "Success init",
+			action:   "init",
+			provider: "metal3:v0.3.1",
+		},
+		{
+			name:     "Success clusterctl repository",
+			action:   "init",
+			provider: "aws:v0.5.0",
+		},
+		{
+			name:     "Success move",
+			action:   "move",
+			provider: "metal3:v0.3.3",
+		},
+		{
+			name:        "Error unknown action",
+			action:      "unknown",
+			provider:    "metal3:v0.3.1",
+			expectedErr: cctlclient.ErrUnknownExecutorAction{Action: "unknown"},
+		},
+		{
+			name:     "Error provider not defined",
+			action:   "init",
+			provider: "openstack:v0.3.1",
+			expectedErr: cctlclient.ErrProviderRepoNotFound{
+				ProviderName: "openstack",
+				ProviderType: "InfrastructureProvider",
+			},
+		},
+		{
+			name:        "Error version not defined",
+			action:      "init",
+			provider:    "metal3:v0.3.2",
+			expectedErr: cctlclient.ErrProviderVersionNotDefined{ProviderName: "metal3", Version: "v0.3.2"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cfgDoc, err := document.NewDocumentFromBytes([]byte(fmt.Sprintf(validateConfigTmpl, tt.action, tt.provider)))
+			require.NoError(t, err)
+			executor, err := cctlclient.NewExecutor(
+				ifc.ExecutorConfig{
+					ExecutorDocument: cfgDoc,
+					Helper:           makeDefaultHelper(t),
+				})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedErr, executor.Validate())
 		})
-	require.NoError(t, err)
-	expectedErr := airerrors.ErrNotImplemented{}
-	actualErr := executor.Validate()
-	assert.Equal(t, expectedErr, actualErr)
+	}
+
+	t.Run("Error version path doesn't exist", func(t *testing.T) {
+		cfgDoc, err := document.NewDocumentFromBytes([]byte(fmt.Sprintf(validateConfigTmpl, "init", "metal3:v0.3.3")))
+		require.NoError(t, err)
+		executor, err := cctlclient.NewExecutor(
+			ifc.ExecutorConfig{
+				ExecutorDocument: cfgDoc,
+				Helper:           makeDefaultHelper(t),
+			})
+		require.NoError(t, err)
+		err = executor.Validate()
+		require.Error(t, err)
+		assert.IsType(t, cctlclient.ErrProviderVersionPath{}, err)
+	})
 }
+
+func TestExecutorDetails(t *testing.T) {
+	tests := []struct {
+		name            string
+		action          string
+		clusterMap      clustermap.ClusterMap
+		expectedDetails string
+		expectedErr     error
+	}{
+		{
+			name:            "init",
+			action:          "init",
+			expectedDetails: "clusterctl init with providers cluster-api:v0.3.3 on target-cluster",
+		},
+		{
+			name:   "move",
+			action: "move",
+			clusterMap: clustermap.NewClusterMap(&airshipv1.ClusterMap{
+				Map: map[string]*airshipv1.Cluster{
+					"ephemeral-cluster": {},
+					"target-cluster":    {Parent: "ephemeral-cluster", Namespace: "target-infra"},
+				},
+			}),
+			expectedDetails: "clusterctl move of namespace target-infra from ephemeral-cluster to target-cluster",
+		},
+		{
+			name:   "move default namespace",
+			action: "move",
+			clusterMap: clustermap.NewClusterMap(&airshipv1.ClusterMap{
+				Map: map[string]*airshipv1.Cluster{
+					"ephemeral-cluster": {},
+					"target-cluster":    {Parent: "ephemeral-cluster"},
+				},
+			}),
+			expectedDetails: "clusterctl move of namespace default from ephemeral-cluster to target-cluster",
+		},
+		{
+			name:        "unknown action",
+			action:      "unknown",
+			expectedErr: cctlclient.ErrUnknownExecutorAction{Action: "unknown"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			executor, err := cctlclient.NewExecutor(
+				ifc.ExecutorConfig{
+					ExecutorDocument: executorDoc(t, tt.action),
+					Helper:           makeDefaultHelper(t),
+					ClusterMap:       tt.clusterMap,
+					ClusterName:      "target-cluster",
+				})
+			require.NoError(t, err)
+			details, err := executor.Details()
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedDetails, details)
+		})
+	}
+}
+
 func TestExecutorRender(t *testing.T) {
-	sampleCfgDoc := executorDoc(t, "init")
-	executor, err := cctlclient.NewExecutor(
-		ifc.ExecutorConfig{
-			ExecutorDocument: sampleCfgDoc,
-			Helper:           makeDefaultHelper(t),
+	renderConfigTmpl := `
+apiVersion: airshipit.org/v1alpha1
+kind: Clusterctl
+metadata:
+  name: clusterctl-v1
+action: %s
+init-options:
+  core-provider: "cluster-api:v0.3.2"
+  infrastructure-providers:
+    - "metal3:v0.1.0"
+providers:
+  - name: "cluster-api"
+    type: "CoreProvider"
+    versions:
+      v0.3.2: functions/capi/infrastructure/v0.3.2
+  - name: "metal3"
+    type: "InfrastructureProvider"
+    versions:
+      v0.1.0: functions/capi/variables/v0.1.0
+%s`
+	additionalVars := `additional-vars:
+  RENDER_VALUE: rendered`
+
+	testCases := []struct {
+		name          string
+		cfgDoc        string
+		selector      document.Selector
+		expectedNames []string
+		expectedErr   error
+	}{
+		{
+			name:          "Render components of all providers",
+			cfgDoc:        fmt.Sprintf(renderConfigTmpl, "init", additionalVars),
+			expectedNames: []string{"version-two", "render-system", "render-config"},
+		},
+		{
+			name:          "Render components filtered by selector",
+			cfgDoc:        fmt.Sprintf(renderConfigTmpl, "init", additionalVars),
+			selector:      document.NewSelector().ByKind("ConfigMap"),
+			expectedNames: []string{"render-config"},
+		},
+		{
+			name:   "Error variables are not set",
+			cfgDoc: fmt.Sprintf(renderConfigTmpl, "init", ""),
+			expectedErr: cctlclient.ErrUnresolvedVariables{
+				ProviderName: "metal3",
+				ProviderType: "InfrastructureProvider",
+				Variables:    []string{"RENDER_VALUE"},
+			},
+		},
+		{
+			name:          "Nothing is rendered for move",
+			cfgDoc:        fmt.Sprintf(renderConfigTmpl, "move", additionalVars),
+			expectedNames: []string{},
+		},
+	}
+	for _, test := range testCases {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			cfgDoc, err := document.NewDocumentFromBytes([]byte(tt.cfgDoc))
+			require.NoError(t, err)
+			executor, err := cctlclient.NewExecutor(
+				ifc.ExecutorConfig{
+					ExecutorDocument: cfgDoc,
+					Helper:           makeDefaultHelper(t),
+				})
+			require.NoError(t, err)
+			actualOut := &bytes.Buffer{}
+			actualErr := executor.Render(actualOut, ifc.RenderOptions{FilterSelector: tt.selector})
+			assert.Equal(t, tt.expectedErr, actualErr)
+			if tt.expectedErr != nil {
+				return
+			}
+
+			bundle, err := document.NewBundleFromBytes(actualOut.Bytes())
+			require.NoError(t, err)
+			docs, err := bundle.GetAllDocuments()
+			require.NoError(t, err)
+			actualNames := []string{}
+			for _, doc := range docs {
+				actualNames = append(actualNames, doc.GetName())
+			}
+			assert.ElementsMatch(t, tt.expectedNames, actualNames)
+			// variables must be substituted
+			assert.NotContains(t, actualOut.String(), "${")
 		})
-	require.NoError(t, err)
-	actualOut := &bytes.Buffer{}
-	actualErr := executor.Render(actualOut, ifc.RenderOptions{})
-	assert.Equal(t, nil, actualErr)
+	}
 }
 
 func makeDefaultHelper(t *testing.T) ifc.Helper {
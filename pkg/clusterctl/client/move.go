@@ -34,10 +34,10 @@ func init() {
 	bmoapis.AddToScheme(cluster.Scheme)
 }
 
-// Move implements interface to Clusterctl
-func (c *Client) Move(fromKubeconfigPath, fromKubeconfigContext,
+// Move implements interface to Clusterctl, the move is not started if the context
+// is cancelled while BareMetalHost objects are being paused
+func (c *Client) Move(ctx context.Context, fromKubeconfigPath, fromKubeconfigContext,
 	toKubeconfigPath, toKubeconfigContext, namespace string) error {
-	ctx := context.TODO()
 	var err error
 	// ephemeral cluster client
 	pFrom := cluster.New(cluster.Kubeconfig{
@@ -76,10 +76,19 @@ func (c *Client) Move(fromKubeconfigPath, fromKubeconfigContext,
 		ToKubeconfig:   clusterctlclient.Kubeconfig{Path: toKubeconfigPath, Context: toKubeconfigContext},
 		Namespace:      namespace,
 	}
+	// clusterctl move can't be interrupted, so make sure the move is not cancelled before it is started
+	if err = ctx.Err(); err != nil {
+		if unpauseErr := pauseUnpauseBMHs(context.Background(), cFrom, namespace, false); unpauseErr != nil {
+			log.Printf("Failed to unpause BareMetalHost objects: %v", unpauseErr)
+		}
+		return err
+	}
 	err = c.clusterctlClient.Move(c.moveOptions)
 	if err != nil {
 		return errors.Wrapf(err, "error during clusterctl move")
 	}
+	// objects are moved at this point, so BareMetalHost objects must be updated even if the move is cancelled
+	ctx = context.Background()
 	// Update BMH Status
 	err = copyBMHStatus(ctx, cFrom, cTo, namespace)
 	if err != nil {
@@ -15,6 +15,8 @@
 package cmd
 
 import (
+	"context"
+
 	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/clusterctl/client"
 	"opendev.org/airship/airshipctl/pkg/config"
@@ -99,9 +101,10 @@ func getBundle(conf *config.Config) (document.Bundle, error) {
 
 // Move runs clusterctl move
 func (c *Command) Move(toKubeconfigContext string) error {
+	ctx := context.Background()
 	if c.options.MoveOptions != nil {
-		return c.client.Move(c.kubeconfigPath, c.kubeconfigContext,
+		return c.client.Move(ctx, c.kubeconfigPath, c.kubeconfigContext,
 			c.kubeconfigPath, toKubeconfigContext, c.options.MoveOptions.Namespace)
 	}
-	return c.client.Move(c.kubeconfigPath, c.kubeconfigContext, c.kubeconfigPath, toKubeconfigContext, "")
+	return c.client.Move(ctx, c.kubeconfigPath, c.kubeconfigContext, c.kubeconfigPath, toKubeconfigContext, "")
 }
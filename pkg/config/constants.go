@@ -50,6 +50,8 @@ const (
 	AirshipDefaultManifest                = "default"
 	AirshipDefaultManifestRepo            = "treasuremap"
 	AirshipDefaultManifestRepoLocation    = "https://opendev.org/airship/" + AirshipDefaultManifestRepo
+	AirshipExecutorPluginPath             = "executor-plugins"
+	AirshipExecutorPluginPathEnv          = "AIRSHIP_EXECUTOR_PLUGINS"
 	AirshipKubeConfig                     = "kubeconfig"
 	AirshipKubeConfigEnv                  = "AIRSHIP_KUBECONFIG"
 	AirshipPluginPath                     = "kustomize-plugins"
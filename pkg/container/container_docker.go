@@ -15,6 +15,7 @@
 package container
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -26,6 +27,7 @@ import (
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 
 	"opendev.org/airship/airshipctl/pkg/log"
 )
@@ -175,6 +177,8 @@ func (c *DockerContainer) getConfig(
 		AttachStdin: true,
 		OpenStdin:   true,
 		Env:         envVars,
+		// close container STDIN once input is written, so the command gets EOF
+		StdinOnce: true,
 	}
 	hCfg := container.HostConfig{
 		Binds: volumeMounts,
@@ -261,23 +265,30 @@ func (c *DockerContainer) RunCommand(
 
 	c.id = resp.ID
 
+	var conn *types.HijackedResponse
 	if containerInput != nil {
-		conn, attachErr := c.dockerClient.ContainerAttach(*c.ctx, c.id, types.ContainerAttachOptions{
+		attached, attachErr := c.dockerClient.ContainerAttach(*c.ctx, c.id, types.ContainerAttachOptions{
 			Stream: true,
 			Stdin:  true,
 		})
 		if attachErr != nil {
 			return attachErr
 		}
-		if _, err = io.Copy(conn.Conn, containerInput); err != nil {
-			return err
-		}
+		defer attached.Close()
+		conn = &attached
 	}
 
 	if err = c.dockerClient.ContainerStart(*c.ctx, c.id, types.ContainerStartOptions{}); err != nil {
 		return err
 	}
 
+	// input is written once the container is started, otherwise writing blocks
+	// as soon as the input exceeds connection buffers, since nobody reads it
+	inputErr := make(chan error, 1)
+	go func() {
+		inputErr <- writeInput(conn, containerInput)
+	}()
+
 	if debug {
 		log.Debug("start reading container logs")
 		var reader io.ReadCloser
@@ -294,6 +305,10 @@ func (c *DockerContainer) RunCommand(
 		log.Debug("got EOF from container logs")
 	}
 
+	if err = <-inputErr; err != nil {
+		return err
+	}
+
 	statusCh, errCh := c.dockerClient.ContainerWait(*c.ctx, c.id, container.WaitConditionNotRunning)
 	log.Debugf("waiting until command '%s' is finished...", realCmd)
 	select {
@@ -311,8 +326,19 @@ func (c *DockerContainer) RunCommand(
 	return nil
 }
 
+// writeInput writes input to the STDIN of the attached container and closes it
+func writeInput(conn *types.HijackedResponse, input io.Reader) error {
+	if conn == nil {
+		return nil
+	}
+	if _, err := io.Copy(conn.Conn, input); err != nil {
+		return err
+	}
+	return conn.CloseWrite()
+}
+
 // RunCommandOutput executes specified command in Docker container and
-// returns command output as ReadCloser object. RunCommand debug option is
+// returns command STDOUT as ReadCloser object. RunCommand debug option is
 // set to false explicitly
 func (c *DockerContainer) RunCommandOutput(
 	cmd []string,
@@ -324,13 +350,27 @@ func (c *DockerContainer) RunCommandOutput(
 		return nil, err
 	}
 
-	return c.dockerClient.ContainerLogs(*c.ctx, c.id, types.ContainerLogsOptions{ShowStdout: true})
+	logs, err := c.dockerClient.ContainerLogs(*c.ctx, c.id, types.ContainerLogsOptions{ShowStdout: true})
+	if err != nil {
+		return nil, err
+	}
+	defer logs.Close()
+
+	// container is created without TTY, so docker multiplexes its output streams
+	// and every chunk of the log is prefixed with a stream header
+	stdout := &bytes.Buffer{}
+	if _, err = stdcopy.StdCopy(stdout, ioutil.Discard, logs); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(stdout), nil
 }
 
 // RmContainer kills and removes a container from the docker host.
+// Container is removed with a new context, so it can be cleaned up
+// after the context of the command run was cancelled
 func (c *DockerContainer) RmContainer() error {
 	return c.dockerClient.ContainerRemove(
-		*c.ctx,
+		context.Background(),
 		c.id,
 		types.ContainerRemoveOptions{
 			Force: true,
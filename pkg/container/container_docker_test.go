@@ -15,6 +15,7 @@
 package container
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -30,6 +31,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/pkg/stdcopy"
 )
 
 type mockConn struct {
@@ -48,6 +50,22 @@ func (mc mockConn) SetDeadline(time.Time) error      { return nil }
 func (mc mockConn) SetReadDeadline(time.Time) error  { return nil }
 func (mc mockConn) SetWriteDeadline(time.Time) error { return nil }
 
+// startedConn fails writes made before the container is started
+type startedConn struct {
+	mockConn
+	started <-chan struct{}
+	err     error
+}
+
+func (sc startedConn) Write(b []byte) (n int, err error) {
+	select {
+	case <-sc.started:
+		return len(b), nil
+	default:
+		return 0, sc.err
+	}
+}
+
 type mockDockerClient struct {
 	imageInspectWithRaw func() (types.ImageInspect, []byte, error)
 	imageList           func() ([]types.ImageSummary, error)
@@ -272,6 +290,8 @@ func TestRunCommand(t *testing.T) {
 	attachError := fmt.Errorf("attach error")
 	containerStartError := fmt.Errorf("container start error")
 	containerWaitError := fmt.Errorf("container wait error")
+	inputError := fmt.Errorf("input is written before container is started")
+	started := make(chan struct{})
 	tests := []struct {
 		cmd              []string
 		containerInput   io.Reader
@@ -332,6 +352,36 @@ func TestRunCommand(t *testing.T) {
 			expectedErr: attachError,
 			assertF:     func(t *testing.T) {},
 		},
+		{
+			cmd:            []string{"testCmd"},
+			containerInput: strings.NewReader("testInput"),
+			volumeMounts:   nil,
+			debug:          false,
+			mockDockerClient: mockDockerClient{
+				containerAttach: func() (types.HijackedResponse, error) {
+					return types.HijackedResponse{Conn: startedConn{started: started, err: inputError}}, nil
+				},
+				containerStart: func() error {
+					close(started)
+					return nil
+				},
+			},
+			expectedErr: nil,
+			assertF:     func(t *testing.T) {},
+		},
+		{
+			cmd:            []string{"testCmd"},
+			containerInput: strings.NewReader("testInput"),
+			volumeMounts:   nil,
+			debug:          false,
+			mockDockerClient: mockDockerClient{
+				containerAttach: func() (types.HijackedResponse, error) {
+					return types.HijackedResponse{Conn: startedConn{started: make(chan struct{}), err: inputError}}, nil
+				},
+			},
+			expectedErr: inputError,
+			assertF:     func(t *testing.T) {},
+		},
 		{
 			cmd:            []string{"testCmd"},
 			containerInput: nil,
@@ -440,6 +490,41 @@ func TestRunCommandOutput(t *testing.T) {
 			expectedResult: "",
 			expectedErr:    testError,
 		},
+		{
+			cmd:            []string{"testCmd"},
+			containerInput: strings.NewReader("testInput"),
+			volumeMounts:   nil,
+			mockDockerClient: mockDockerClient{
+				containerAttach: func() (types.HijackedResponse, error) {
+					conn := types.HijackedResponse{
+						Conn: mockConn{WData: make([]byte, len([]byte("testInput")))},
+					}
+					return conn, nil
+				},
+				containerLogs: func() (io.ReadCloser, error) {
+					logs := &bytes.Buffer{}
+					_, err := stdcopy.NewStdWriter(logs, stdcopy.Stdout).Write([]byte("stdout output"))
+					require.NoError(t, err)
+					_, err = stdcopy.NewStdWriter(logs, stdcopy.Stderr).Write([]byte("stderr output"))
+					require.NoError(t, err)
+					return ioutil.NopCloser(logs), nil
+				},
+			},
+			expectedResult: "stdout output",
+			expectedErr:    nil,
+		},
+		{
+			cmd:            []string{"testCmd"},
+			containerInput: nil,
+			volumeMounts:   nil,
+			mockDockerClient: mockDockerClient{
+				containerLogs: func() (io.ReadCloser, error) {
+					return nil, testError
+				},
+			},
+			expectedResult: "",
+			expectedErr:    testError,
+		},
 	}
 	for _, tt := range tests {
 		cnt := getDockerContainerMock(tt.mockDockerClient)
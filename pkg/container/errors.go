@@ -16,6 +16,8 @@ package container
 
 import (
 	"fmt"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 )
 
 // ErrEmptyImageList returned if no image defined in filter found
@@ -52,3 +54,30 @@ type ErrNoContainerDriver struct {
 func (e ErrNoContainerDriver) Error() string {
 	return fmt.Sprintf("container runtime is not defined in airshipctl config")
 }
+
+// ErrGenericContainerNilBundle returned if generic container executor is created without document bundle
+type ErrGenericContainerNilBundle struct {
+}
+
+func (e ErrGenericContainerNilBundle) Error() string {
+	return "Cannot run generic container with empty bundle"
+}
+
+// ErrUnknownOutputType returned if output type of the generic container is not supported
+type ErrUnknownOutputType struct {
+	Type v1alpha1.GenericContainerOutputType
+}
+
+func (e ErrUnknownOutputType) Error() string {
+	return fmt.Sprintf("unknown generic container output type '%s', supported types are: %s, %s",
+		e.Type, v1alpha1.GenericContainerOutputApply, v1alpha1.GenericContainerOutputDirectory)
+}
+
+// ErrUnexpectedOutputKind returned if container output is not a ResourceList
+type ErrUnexpectedOutputKind struct {
+	Kind string
+}
+
+func (e ErrUnexpectedOutputKind) Error() string {
+	return fmt.Sprintf("container output must be of %s kind, got '%s'", ResourceListKind, e.Kind)
+}
@@ -0,0 +1,382 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package container
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/k8s/applier"
+	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/k8s/utils"
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const (
+	// ResourceListAPIVersion is an API version of the KRM function input and output
+	ResourceListAPIVersion = "config.kubernetes.io/v1alpha1"
+	// ResourceListKind is a kind of the KRM function input and output
+	ResourceListKind = "ResourceList"
+
+	// OutputResourcesFileName is a name of the file output documents are written to
+	OutputResourcesFileName = "resources.yaml"
+	// OutputKustomizationFileName is a name of the kustomization file, which makes
+	// output directory usable as a document entrypoint of the other phase
+	OutputKustomizationFileName = "kustomization.yaml"
+)
+
+var _ ifc.Executor = &Executor{}
+
+// RegisterExecutor adds executor to phase executor registry
+func RegisterExecutor(registry map[schema.GroupVersionKind]ifc.ExecutorFactory) error {
+	obj := &v1alpha1.GenericContainer{}
+	gvks, _, err := v1alpha1.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return err
+	}
+	registry[gvks[0]] = NewExecutor
+	return nil
+}
+
+// Executor runs KRM function container against documents of the phase
+type Executor struct {
+	ExecutorBundle   document.Bundle
+	ExecutorDocument document.Document
+
+	phaseName         string
+	clusterName       string
+	kubeconfigContext string
+	targetPath        string
+	kubeconfig        kubeconfig.Interface
+	apiObj            *v1alpha1.GenericContainer
+	runner            Container
+}
+
+// NewExecutor creates instance of phase executor
+func NewExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
+	apiObj := &v1alpha1.GenericContainer{}
+	err := cfg.ExecutorDocument.ToAPIObject(apiObj, v1alpha1.Scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := cfg.BundleFactory()
+	if err != nil {
+		return nil, err
+	}
+
+	targetPath := ""
+	if cfg.Helper != nil {
+		targetPath = cfg.Helper.TargetPath()
+	}
+
+	return &Executor{
+		ExecutorBundle:    bundle,
+		ExecutorDocument:  cfg.ExecutorDocument,
+		phaseName:         cfg.PhaseName,
+		clusterName:       cfg.ClusterName,
+		kubeconfigContext: cfg.KubeconfigContext,
+		targetPath:        targetPath,
+		kubeconfig:        cfg.KubeConfig,
+		apiObj:            apiObj,
+	}, nil
+}
+
+// Run KRM function container as a phase runner
+func (c *Executor) Run(ctx context.Context, evtCh chan events.Event, opts ifc.RunOptions) {
+	if c.ExecutorBundle == nil {
+		handleError(evtCh, ErrGenericContainerNilBundle{})
+		close(evtCh)
+		return
+	}
+	// output settings are checked before the container is started, so that its work is not lost
+	if err := c.validateOutput(); err != nil {
+		handleError(evtCh, err)
+		close(evtCh)
+		return
+	}
+
+	evtCh <- events.Event{
+		Type: events.GenericContainerType,
+		GenericContainerEvent: events.GenericContainerEvent{
+			Operation: events.GenericContainerStart,
+			Message:   fmt.Sprintf("starting container %s", c.apiObj.Spec.Image),
+		},
+	}
+
+	if opts.DryRun {
+		log.Printf("container %s will be executed", c.apiObj.Spec.Image)
+		evtCh <- events.Event{
+			Type: events.GenericContainerType,
+			GenericContainerEvent: events.GenericContainerEvent{
+				Operation: events.GenericContainerStop,
+			},
+		}
+		close(evtCh)
+		return
+	}
+
+	output, err := c.runFunction(ctx)
+	if err != nil {
+		handleError(evtCh, err)
+		close(evtCh)
+		return
+	}
+
+	evtCh <- events.Event{
+		Type: events.GenericContainerType,
+		GenericContainerEvent: events.GenericContainerEvent{
+			Operation: events.GenericContainerStop,
+			Message:   "container execution is complete, processing output documents",
+		},
+	}
+
+	switch c.apiObj.Output.Type {
+	case v1alpha1.GenericContainerOutputApply:
+		// applier closes the channel when it's done
+		c.applyOutput(ctx, evtCh, output)
+		return
+	case v1alpha1.GenericContainerOutputDirectory:
+		err = c.writeOutput(output)
+	default:
+		err = ErrUnknownOutputType{Type: c.apiObj.Output.Type}
+	}
+	if err != nil {
+		handleError(evtCh, err)
+	}
+	close(evtCh)
+}
+
+// runFunction passes documents of the phase to the container and returns documents
+// produced by the container, container is removed if it has failed or the run is cancelled
+func (c *Executor) runFunction(ctx context.Context) (document.Bundle, error) {
+	input, err := c.resourceList()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.runner == nil {
+		runner, runnerErr := NewContainer(&ctx, c.apiObj.Spec.ContainerRuntime, c.apiObj.Spec.Image)
+		if runnerErr != nil {
+			return nil, runnerErr
+		}
+		c.runner = runner
+	}
+
+	output, err := c.runner.RunCommandOutput(
+		c.apiObj.Spec.Command,
+		bytes.NewReader(input),
+		c.apiObj.Spec.Mounts,
+		c.apiObj.Spec.EnvVars)
+	if err != nil {
+		c.removeFailedContainer()
+		return nil, err
+	}
+	defer output.Close()
+
+	data, err := ioutil.ReadAll(output)
+	if err != nil {
+		c.removeFailedContainer()
+		return nil, err
+	}
+
+	if log.DebugEnabled() {
+		log.Debugf("Debug flag is set. Container %s stopped but not deleted.", c.runner.GetID())
+	} else if err = c.runner.RmContainer(); err != nil {
+		return nil, err
+	}
+	return parseResourceList(data)
+}
+
+// removeFailedContainer removes container which has failed or was cancelled, removal error
+// is only logged so that the original error is reported
+func (c *Executor) removeFailedContainer() {
+	if c.runner.GetID() == "" {
+		return
+	}
+	if err := c.runner.RmContainer(); err != nil {
+		log.Printf("Failed to remove container %s: %v", c.runner.GetID(), err)
+	}
+}
+
+// resourceList builds KRM function input from documents of the phase
+func (c *Executor) resourceList() ([]byte, error) {
+	docs, err := c.ExecutorBundle.GetAllDocuments()
+	if err != nil {
+		return nil, err
+	}
+
+	items := []interface{}{}
+	for _, doc := range docs {
+		item := map[string]interface{}{}
+		if err = doc.ToObject(&item); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	resourceList := map[string]interface{}{
+		"apiVersion": ResourceListAPIVersion,
+		"kind":       ResourceListKind,
+		"items":      items,
+	}
+	if c.apiObj.Config != "" {
+		functionConfig := map[string]interface{}{}
+		if err = yaml.Unmarshal([]byte(c.apiObj.Config), &functionConfig); err != nil {
+			return nil, err
+		}
+		resourceList["functionConfig"] = functionConfig
+	}
+	return yaml.Marshal(resourceList)
+}
+
+// parseResourceList returns bundle with documents from KRM function output
+func parseResourceList(data []byte) (document.Bundle, error) {
+	resourceList := struct {
+		Kind  string                   `json:"kind"`
+		Items []map[string]interface{} `json:"items"`
+	}{}
+	if err := yaml.Unmarshal(data, &resourceList); err != nil {
+		return nil, err
+	}
+	if resourceList.Kind != ResourceListKind {
+		return nil, ErrUnexpectedOutputKind{Kind: resourceList.Kind}
+	}
+
+	buf := &bytes.Buffer{}
+	for _, item := range resourceList.Items {
+		doc, err := yaml.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString("---\n")
+		buf.Write(doc)
+	}
+	return document.NewBundleFromBytes(buf.Bytes())
+}
+
+// applyOutput applies output documents to kubernetes cluster
+func (c *Executor) applyOutput(ctx context.Context, evtCh chan events.Event, bundle document.Bundle) {
+	log.Debug("Getting kubeconfig file information from kubeconfig provider")
+	path, cleanup, err := c.kubeconfig.GetFile()
+	if err != nil {
+		handleError(evtCh, err)
+		close(evtCh)
+		return
+	}
+	defer cleanup()
+
+	factory := utils.FactoryFromKubeConfig(path, c.kubeconfigContext)
+	applier.NewApplier(evtCh, factory, utils.Streams()).ApplyBundle(ctx, bundle,
+		applier.NewApplyOptions(c.apiObj.Output.Apply, c.phaseName))
+}
+
+// writeOutput writes output documents to the output directory along with kustomization file
+func (c *Executor) writeOutput(bundle document.Bundle) error {
+	dir := c.outputDir()
+	log.Debugf("Writing output documents to %s", dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(filepath.Join(dir, OutputResourcesFileName))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err = bundle.Write(f); err != nil {
+		return err
+	}
+
+	kustomization := []byte("resources:\n- " + OutputResourcesFileName + "\n")
+	return ioutil.WriteFile(filepath.Join(dir, OutputKustomizationFileName), kustomization, 0644)
+}
+
+func (c *Executor) outputDir() string {
+	return filepath.Join(c.targetPath, c.apiObj.Output.Path)
+}
+
+// Validate executor configuration and documents
+func (c *Executor) Validate() error {
+	if c.ExecutorBundle == nil {
+		return ErrGenericContainerNilBundle{}
+	}
+	if c.apiObj.Spec.Image == "" {
+		return config.ErrMissingConfig{What: "Must specify image for generic container"}
+	}
+
+	if err := c.validateOutput(); err != nil {
+		return err
+	}
+
+	_, err := c.resourceList()
+	return err
+}
+
+// validateOutput makes sure that output type is known and has required settings
+func (c *Executor) validateOutput() error {
+	switch c.apiObj.Output.Type {
+	case v1alpha1.GenericContainerOutputApply:
+		return applier.ValidateApplyConfig(c.apiObj.Output.Apply)
+	case v1alpha1.GenericContainerOutputDirectory:
+		if c.apiObj.Output.Path == "" {
+			return config.ErrMissingConfig{What: "Must specify path for generic container directory output"}
+		}
+		return nil
+	default:
+		return ErrUnknownOutputType{Type: c.apiObj.Output.Type}
+	}
+}
+
+// Details returns summary of the container execution
+func (c *Executor) Details() (string, error) {
+	output := fmt.Sprintf("applies output documents to %s", c.clusterName)
+	if c.apiObj.Output.Type == v1alpha1.GenericContainerOutputDirectory {
+		output = fmt.Sprintf("writes output documents to %s", c.outputDir())
+	}
+	return fmt.Sprintf("runs %s image using %s runtime and %s",
+		c.apiObj.Spec.Image, c.apiObj.Spec.ContainerRuntime, output), nil
+}
+
+// Render documents passed to the container
+func (c *Executor) Render(w io.Writer, o ifc.RenderOptions) error {
+	bundle, err := c.ExecutorBundle.SelectBundle(o.FilterSelector)
+	if err != nil {
+		return err
+	}
+	return bundle.Write(w)
+}
+
+func handleError(ch chan<- events.Event, err error) {
+	ch <- events.Event{
+		Type: events.ErrorType,
+		ErrorEvent: events.ErrorEvent{
+			Error: err,
+		},
+	}
+}
@@ -0,0 +1,451 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package container
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/k8s/applier"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/testutil"
+)
+
+const (
+	executorDoc = `
+apiVersion: airshipit.org/v1alpha1
+kind: GenericContainer
+metadata:
+  name: krm-function
+  labels:
+    airshipit.org/deploy-k8s: "false"
+spec:
+  image: quay.io/airshipit/krm-function:latest
+  containerRuntime: docker
+output:
+  type: directory
+  path: functions/output
+config: |
+  apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: function-config
+  data:
+    key: value
+`
+	inputDocs = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: input
+data:
+  key: value
+`
+	outputResourceList = `
+apiVersion: config.kubernetes.io/v1alpha1
+kind: ResourceList
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: input
+  data:
+    key: value
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    name: generated
+  type: Opaque
+`
+)
+
+type mockContainer struct {
+	input       []byte
+	output      string
+	outputErr   error
+	rmContainer func() error
+}
+
+func (mc *mockContainer) ImagePull() error {
+	return nil
+}
+
+func (mc *mockContainer) RunCommand([]string, io.Reader, []string, []string, bool) error {
+	return nil
+}
+
+func (mc *mockContainer) RunCommandOutput(_ []string, in io.Reader, _ []string, _ []string) (io.ReadCloser, error) {
+	if mc.outputErr != nil {
+		return nil, mc.outputErr
+	}
+	input, err := ioutil.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+	mc.input = input
+	return ioutil.NopCloser(strings.NewReader(mc.output)), nil
+}
+
+func (mc *mockContainer) RmContainer() error {
+	return mc.rmContainer()
+}
+
+func (mc *mockContainer) GetID() string {
+	return "TESTID"
+}
+
+func testBundleFactory(data string) document.BundleFactoryFunc {
+	return func() (document.Bundle, error) {
+		return document.NewBundleFromBytes([]byte(data))
+	}
+}
+
+func testExecutor(t *testing.T, targetPath string, runner Container) *Executor {
+	execDoc, err := document.NewDocumentFromBytes([]byte(executorDoc))
+	require.NoError(t, err)
+	executor, err := NewExecutor(ifc.ExecutorConfig{
+		PhaseName:        "krm-function",
+		ClusterName:      "ephemeral-cluster",
+		ExecutorDocument: execDoc,
+		BundleFactory:    testBundleFactory(inputDocs),
+	})
+	require.NoError(t, err)
+	e, ok := executor.(*Executor)
+	require.True(t, ok)
+	e.targetPath = targetPath
+	e.runner = runner
+	return e
+}
+
+func TestRegisterExecutor(t *testing.T) {
+	registry := make(map[schema.GroupVersionKind]ifc.ExecutorFactory)
+	expectedGVK := schema.GroupVersionKind{
+		Group:   "airshipit.org",
+		Version: "v1alpha1",
+		Kind:    "GenericContainer",
+	}
+	err := RegisterExecutor(registry)
+	require.NoError(t, err)
+
+	_, found := registry[expectedGVK]
+	assert.True(t, found)
+}
+
+func TestNewExecutor(t *testing.T) {
+	executor := testExecutor(t, "", nil)
+	assert.Equal(t, "quay.io/airshipit/krm-function:latest", executor.apiObj.Spec.Image)
+	assert.Equal(t, v1alpha1.GenericContainerOutputDirectory, executor.apiObj.Output.Type)
+	assert.Equal(t, "functions/output", executor.apiObj.Output.Path)
+}
+
+func TestExecutorRun(t *testing.T) {
+	testErr := fmt.Errorf("container failed")
+
+	testCases := []struct {
+		name        string
+		runner      *mockContainer
+		cfgFunc     func(*v1alpha1.GenericContainer)
+		runOptions  ifc.RunOptions
+		expectedEvt []events.Event
+		outputDocs  []string
+	}{
+		{
+			name: "Run container successfully",
+			runner: &mockContainer{
+				output:      outputResourceList,
+				rmContainer: func() error { return nil },
+			},
+			expectedEvt: []events.Event{
+				{
+					Type: events.GenericContainerType,
+					GenericContainerEvent: events.GenericContainerEvent{
+						Operation: events.GenericContainerStart,
+					},
+				},
+				{
+					Type: events.GenericContainerType,
+					GenericContainerEvent: events.GenericContainerEvent{
+						Operation: events.GenericContainerStop,
+					},
+				},
+			},
+			outputDocs: []string{"input", "generated"},
+		},
+		{
+			name:       "Skip container in dry-run mode",
+			runner:     &mockContainer{},
+			runOptions: ifc.RunOptions{DryRun: true},
+			expectedEvt: []events.Event{
+				{
+					Type: events.GenericContainerType,
+					GenericContainerEvent: events.GenericContainerEvent{
+						Operation: events.GenericContainerStart,
+					},
+				},
+				{
+					Type: events.GenericContainerType,
+					GenericContainerEvent: events.GenericContainerEvent{
+						Operation: events.GenericContainerStop,
+					},
+				},
+			},
+		},
+		{
+			name: "Fail on container command",
+			runner: &mockContainer{
+				outputErr:   testErr,
+				rmContainer: func() error { return fmt.Errorf("rm failed") },
+			},
+			expectedEvt: []events.Event{
+				{
+					Type: events.GenericContainerType,
+					GenericContainerEvent: events.GenericContainerEvent{
+						Operation: events.GenericContainerStart,
+					},
+				},
+				{
+					Type:       events.ErrorType,
+					ErrorEvent: events.ErrorEvent{Error: testErr},
+				},
+			},
+		},
+		{
+			name: "Fail on unexpected output",
+			runner: &mockContainer{
+				output:      "kind: ConfigMap",
+				rmContainer: func() error { return nil },
+			},
+			expectedEvt: []events.Event{
+				{
+					Type: events.GenericContainerType,
+					GenericContainerEvent: events.GenericContainerEvent{
+						Operation: events.GenericContainerStart,
+					},
+				},
+				{
+					Type:       events.ErrorType,
+					ErrorEvent: events.ErrorEvent{Error: ErrUnexpectedOutputKind{Kind: "ConfigMap"}},
+				},
+			},
+		},
+		{
+			name:    "Fail on unknown output type",
+			runner:  &mockContainer{},
+			cfgFunc: func(cfg *v1alpha1.GenericContainer) { cfg.Output.Type = "" },
+			expectedEvt: []events.Event{
+				{
+					Type:       events.ErrorType,
+					ErrorEvent: events.ErrorEvent{Error: ErrUnknownOutputType{}},
+				},
+			},
+		},
+		{
+			name:    "Fail on directory output without path",
+			runner:  &mockContainer{},
+			cfgFunc: func(cfg *v1alpha1.GenericContainer) { cfg.Output.Path = "" },
+			expectedEvt: []events.Event{
+				{
+					Type: events.ErrorType,
+					ErrorEvent: events.ErrorEvent{Error: config.ErrMissingConfig{
+						What: "Must specify path for generic container directory output",
+					}},
+				},
+			},
+		},
+	}
+	for _, test := range testCases {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			targetPath, cleanup := testutil.TempDir(t, "generic-container-test")
+			defer cleanup(t)
+
+			executor := testExecutor(t, targetPath, tt.runner)
+			if tt.cfgFunc != nil {
+				tt.cfgFunc(executor.apiObj)
+			}
+			ch := make(chan events.Event)
+			go executor.Run(context.Background(), ch, tt.runOptions)
+			var actualEvt []events.Event
+			for evt := range ch {
+				// Set message to empty string, so it's not compared
+				evt.GenericContainerEvent.Message = ""
+				actualEvt = append(actualEvt, evt)
+			}
+			assert.Equal(t, tt.expectedEvt, actualEvt)
+
+			if tt.outputDocs == nil {
+				return
+			}
+
+			// function must receive documents of the phase and its config
+			input := struct {
+				Kind           string                   `json:"kind"`
+				Items          []map[string]interface{} `json:"items"`
+				FunctionConfig map[string]interface{}   `json:"functionConfig"`
+			}{}
+			require.NoError(t, yaml.Unmarshal(tt.runner.input, &input))
+			assert.Equal(t, ResourceListKind, input.Kind)
+			assert.Len(t, input.Items, 1)
+			assert.Equal(t, "ConfigMap", input.FunctionConfig["kind"])
+
+			// output directory must be usable as a document entrypoint
+			bundle, err := document.NewBundleByPath(filepath.Join(targetPath, "functions/output"))
+			require.NoError(t, err)
+			docs, err := bundle.GetAllDocuments()
+			require.NoError(t, err)
+			names := []string{}
+			for _, doc := range docs {
+				names = append(names, doc.GetName())
+			}
+			assert.ElementsMatch(t, tt.outputDocs, names)
+		})
+	}
+}
+
+func TestExecutorRunCancelled(t *testing.T) {
+	removed := false
+	runner := &mockContainer{
+		outputErr: context.Canceled,
+		rmContainer: func() error {
+			removed = true
+			return nil
+		},
+	}
+	executor := testExecutor(t, "", runner)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ch := make(chan events.Event)
+	go executor.Run(ctx, ch, ifc.RunOptions{})
+	var actualEvt []events.Event
+	for evt := range ch {
+		actualEvt = append(actualEvt, evt)
+	}
+	require.Len(t, actualEvt, 2)
+	assert.Equal(t, events.ErrorEvent{Error: context.Canceled}, actualEvt[1].ErrorEvent)
+	// container must be removed when the run is cancelled
+	assert.True(t, removed)
+}
+
+func TestExecutorRunFailedContainerRemoved(t *testing.T) {
+	removed := false
+	runner := &mockContainer{
+		outputErr: fmt.Errorf("container failed"),
+		rmContainer: func() error {
+			removed = true
+			return nil
+		},
+	}
+	ch := make(chan events.Event)
+	go testExecutor(t, "", runner).Run(context.Background(), ch, ifc.RunOptions{})
+	for range ch {
+	}
+	assert.True(t, removed)
+}
+
+func TestExecutorValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		bundle      bool
+		cfgFunc     func(*v1alpha1.GenericContainer)
+		expectedErr error
+	}{
+		{
+			name:    "Success",
+			bundle:  true,
+			cfgFunc: func(*v1alpha1.GenericContainer) {},
+		},
+		{
+			name:        "Error nil bundle",
+			cfgFunc:     func(*v1alpha1.GenericContainer) {},
+			expectedErr: ErrGenericContainerNilBundle{},
+		},
+		{
+			name:        "Error missing image",
+			bundle:      true,
+			cfgFunc:     func(cfg *v1alpha1.GenericContainer) { cfg.Spec.Image = "" },
+			expectedErr: config.ErrMissingConfig{What: "Must specify image for generic container"},
+		},
+		{
+			name:        "Error unknown output type",
+			bundle:      true,
+			cfgFunc:     func(cfg *v1alpha1.GenericContainer) { cfg.Output.Type = "print" },
+			expectedErr: ErrUnknownOutputType{Type: "print"},
+		},
+		{
+			name:        "Error missing output path",
+			bundle:      true,
+			cfgFunc:     func(cfg *v1alpha1.GenericContainer) { cfg.Output.Path = "" },
+			expectedErr: config.ErrMissingConfig{What: "Must specify path for generic container directory output"},
+		},
+		{
+			name:   "Error negative wait timeout",
+			bundle: true,
+			cfgFunc: func(cfg *v1alpha1.GenericContainer) {
+				cfg.Output.Type = v1alpha1.GenericContainerOutputApply
+				cfg.Output.Apply.WaitOptions.Timeout = -1
+			},
+			expectedErr: applier.ErrInvalidWaitTimeout{Timeout: -1},
+		},
+	}
+	for _, test := range testCases {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			executor := testExecutor(t, "", nil)
+			if !tt.bundle {
+				executor.ExecutorBundle = nil
+			}
+			tt.cfgFunc(executor.apiObj)
+			assert.Equal(t, tt.expectedErr, executor.Validate())
+		})
+	}
+}
+
+func TestExecutorDetails(t *testing.T) {
+	executor := testExecutor(t, "/tmp/target", nil)
+	details, err := executor.Details()
+	require.NoError(t, err)
+	assert.Equal(t, "runs quay.io/airshipit/krm-function:latest image using docker runtime and "+
+		"writes output documents to /tmp/target/functions/output", details)
+
+	executor.apiObj.Output.Type = v1alpha1.GenericContainerOutputApply
+	details, err = executor.Details()
+	require.NoError(t, err)
+	assert.Equal(t, "runs quay.io/airshipit/krm-function:latest image using docker runtime and "+
+		"applies output documents to ephemeral-cluster", details)
+}
+
+func TestExecutorRender(t *testing.T) {
+	executor := testExecutor(t, "", nil)
+	out := &bytes.Buffer{}
+	err := executor.Render(out, ifc.RenderOptions{FilterSelector: document.NewSelector().ByKind("ConfigMap")})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "name: input")
+}
@@ -115,6 +115,29 @@ func NewBundle(fSys FileSystem, kustomizePath string) (Bundle, error) {
 	return bundle, err
 }
 
+// NewBundleFromBytes returns new document.Bundle containing documents from multi-document YAML,
+// documents are taken as is, no kustomize processing is done
+func NewBundleFromBytes(data []byte) (Bundle, error) {
+	resources, err := resource.NewFactory(kunstruct.NewKunstructuredFactoryImpl()).SliceFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceMap := resmap.New()
+	for _, res := range resources {
+		if err = resourceMap.Append(res); err != nil {
+			return nil, err
+		}
+	}
+
+	bundle := &BundleFactory{}
+	if err = bundle.SetFileSystem(NewDocumentFs()); err != nil {
+		return nil, err
+	}
+	err = bundle.SetKustomizeResourceMap(resourceMap)
+	return bundle, err
+}
+
 // PluginPath returns the kustomize plugin path
 func PluginPath() string {
 	if pluginPath == "" {
@@ -35,6 +35,33 @@ func TestNewBundle(t *testing.T) {
 	require.NotNil(bundle)
 }
 
+func TestNewBundleFromBytes(t *testing.T) {
+	data := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+data:
+  key: value
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: second
+type: Opaque
+`)
+	bundle, err := document.NewBundleFromBytes(data)
+	require.NoError(t, err)
+
+	docs, err := bundle.GetAllDocuments()
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "first", docs[0].GetName())
+	assert.Equal(t, "Secret", docs[1].GetKind())
+
+	_, err = document.NewBundleFromBytes([]byte("not: [valid"))
+	assert.Error(t, err)
+}
+
 func TestBundleDocumentFiltering(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -27,3 +27,32 @@ func (e ErrEventReceived) Error() string {
 	// TODO make printing more readable here
 	return fmt.Sprintf("Error events received on channel, errors are:\n%v", e.Errors)
 }
+
+// ErrReplayed is an error restored from the events file, only message of the original error is kept
+type ErrReplayed struct {
+	Message string
+}
+
+func (e ErrReplayed) Error() string {
+	return e.Message
+}
+
+// ErrInvalidEventsFile returned when recorded event can't be read from the events file
+type ErrInvalidEventsFile struct {
+	// Event is a number of the event in the file starting from 1
+	Event int
+	Err   error
+}
+
+func (e ErrInvalidEventsFile) Error() string {
+	return fmt.Sprintf("failed to read event %d from events file: %v", e.Event, e.Err)
+}
+
+// ErrUnknownEventType returned when recorded event has unknown type
+type ErrUnknownEventType struct {
+	Type string
+}
+
+func (e ErrUnknownEventType) Error() string {
+	return fmt.Sprintf("unknown event type %q", e.Type)
+}
@@ -15,6 +15,8 @@
 package events
 
 import (
+	"time"
+
 	applyevent "sigs.k8s.io/cli-utils/pkg/apply/event"
 	statuspollerevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 )
@@ -35,16 +37,41 @@ const (
 	ClusterctlType
 	// IsogenType event emitted by Isogen executor
 	IsogenType
+	// GenericContainerType event emitted by GenericContainer executor
+	GenericContainerType
+	// BaremetalManagerType event emitted by BaremetalManager executor
+	BaremetalManagerType
+	// ExecutorPluginType event emitted by executor plugin
+	ExecutorPluginType
+	// PhaseHookType event emitted when phase hook is executed
+	PhaseHookType
+	// PhaseRetryType event emitted when phase executor is run according to the retry policy
+	PhaseRetryType
+	// ApplyWaveType event emitted by applier when a wave of the bundle is started or finished
+	ApplyWaveType
 )
 
 // Event holds all possible events that can be produced by airship
 type Event struct {
-	Type              Type
-	ApplierEvent      applyevent.Event
-	ErrorEvent        ErrorEvent
-	StatusPollerEvent statuspollerevent.Event
-	ClusterctlEvent   ClusterctlEvent
-	IsogenEvent       IsogenEvent
+	Type                  Type
+	ApplierEvent          applyevent.Event
+	ErrorEvent            ErrorEvent
+	StatusPollerEvent     statuspollerevent.Event
+	WaitEvent             WaitEvent
+	ClusterctlEvent       ClusterctlEvent
+	IsogenEvent           IsogenEvent
+	GenericContainerEvent GenericContainerEvent
+	BaremetalManagerEvent BaremetalManagerEvent
+	ExecutorPluginEvent   ExecutorPluginEvent
+	PhaseHookEvent        PhaseHookEvent
+	PhaseRetryEvent       PhaseRetryEvent
+	ApplyWaveEvent        ApplyWaveEvent
+
+	// Timestamp is a time when the event was received from the phase executor
+	Timestamp time.Time
+	// PhaseName and ClusterName identify the phase which has produced the event
+	PhaseName   string
+	ClusterName string
 }
 
 // ErrorEvent is produced when error is encountered
@@ -52,6 +79,42 @@ type ErrorEvent struct {
 	Error error
 }
 
+// ErrorOf returns error carried by the event or nil if the event doesn't report a failure.
+// Besides error events, applier errors, status poller errors and wait timeouts are failures
+func ErrorOf(e Event) error {
+	switch {
+	case e.Type == ErrorType:
+		return e.ErrorEvent.Error
+	case e.Type == ApplierType && e.ApplierEvent.Type == applyevent.ErrorType:
+		return e.ApplierEvent.ErrorEvent.Err
+	case e.Type == StatusPollerType && e.StatusPollerEvent.EventType == statuspollerevent.ErrorEvent:
+		return e.StatusPollerEvent.Error
+	case e.Type == WaitType && e.WaitEvent.Operation == WaitTimeout:
+		return e.WaitEvent.Error
+	}
+	return nil
+}
+
+// WaitOperation type
+type WaitOperation int
+
+const (
+	// WaitStart operation
+	WaitStart WaitOperation = iota
+	// WaitEnd operation
+	WaitEnd
+	// WaitTimeout operation, resources haven't reached desired status before timeout has expired
+	WaitTimeout
+)
+
+// WaitEvent is produced when airshipctl starts or finishes waiting for resources
+type WaitEvent struct {
+	Operation WaitOperation
+	Message   string
+	// Error is set for WaitTimeout operation
+	Error error
+}
+
 // ClusterctlOperation type
 type ClusterctlOperation int
 
@@ -89,3 +152,111 @@ type IsogenEvent struct {
 	Operation IsogenOperation
 	Message   string
 }
+
+// GenericContainerOperation type
+type GenericContainerOperation int
+
+const (
+	// GenericContainerStart operation
+	GenericContainerStart GenericContainerOperation = iota
+	// GenericContainerStop operation
+	GenericContainerStop
+)
+
+// GenericContainerEvent is produced by generic container executor
+type GenericContainerEvent struct {
+	Operation GenericContainerOperation
+	Message   string
+}
+
+// BaremetalManagerOperation type
+type BaremetalManagerOperation int
+
+const (
+	// BaremetalManagerStart operation
+	BaremetalManagerStart BaremetalManagerOperation = iota
+	// BaremetalManagerComplete operation
+	BaremetalManagerComplete
+)
+
+// BaremetalManagerEvent is produced by baremetal manager executor for every operation performed on a host
+type BaremetalManagerEvent struct {
+	Operation BaremetalManagerOperation
+	HostName  string
+	Message   string
+}
+
+// ExecutorPluginOperation type
+type ExecutorPluginOperation int
+
+const (
+	// ExecutorPluginStart operation
+	ExecutorPluginStart ExecutorPluginOperation = iota
+	// ExecutorPluginMessage operation, message is reported by the plugin
+	ExecutorPluginMessage
+	// ExecutorPluginEnd operation
+	ExecutorPluginEnd
+)
+
+// ExecutorPluginEvent is produced by executor plugin
+type ExecutorPluginEvent struct {
+	Operation ExecutorPluginOperation
+	Message   string
+}
+
+// PhaseHookOperation type
+type PhaseHookOperation int
+
+const (
+	// PhaseHookStart operation
+	PhaseHookStart PhaseHookOperation = iota
+	// PhaseHookEnd operation
+	PhaseHookEnd
+	// PhaseHookWarning operation, hook has failed, but phase execution is continued according to its failure policy
+	PhaseHookWarning
+)
+
+// PhaseHookEvent is produced when pre-run or post-run hook of the phase is executed
+type PhaseHookEvent struct {
+	Operation PhaseHookOperation
+	// Stage is either preRun or postRun
+	Stage   string
+	Hook    string
+	Message string
+}
+
+// PhaseRetryOperation type
+type PhaseRetryOperation int
+
+const (
+	// PhaseRetryAttemptStart operation, executor attempt is started
+	PhaseRetryAttemptStart PhaseRetryOperation = iota
+	// PhaseRetryAttemptFailed operation, executor attempt has failed and is going to be retried
+	PhaseRetryAttemptFailed
+)
+
+// PhaseRetryEvent is produced for every attempt of the phase executor which has a retry policy
+type PhaseRetryEvent struct {
+	Operation   PhaseRetryOperation
+	Attempt     int
+	MaxAttempts int
+	Message     string
+}
+
+// ApplyWaveOperation type
+type ApplyWaveOperation int
+
+const (
+	// ApplyWaveStart operation
+	ApplyWaveStart ApplyWaveOperation = iota
+	// ApplyWaveEnd operation, resources of the wave are applied and have become Current
+	ApplyWaveEnd
+)
+
+// ApplyWaveEvent is produced when applier starts or finishes applying a wave of the bundle
+type ApplyWaveEvent struct {
+	Operation ApplyWaveOperation
+	// Wave is a value of the apply wave annotation shared by the resources of the wave
+	Wave    int
+	Message string
+}
@@ -0,0 +1,244 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	applyevent "sigs.k8s.io/cli-utils/pkg/apply/event"
+	statuspollerevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+// JSONEvent is a machine readable representation of the event written by JSONProcessor.
+// Field names are the same for all event types, fields which don't apply to the event are omitted
+type JSONEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Phase     string    `json:"phase,omitempty"`
+	Cluster   string    `json:"cluster,omitempty"`
+	Type      string    `json:"type"`
+	Operation string    `json:"operation,omitempty"`
+	// Resource is a kubernetes object in Kind/namespace/name format, a host or a hook the event relates to
+	Resource string `json:"resource,omitempty"`
+	Message  string `json:"message,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+var (
+	typeNames = map[Type]string{
+		ApplierType:          "Applier",
+		ErrorType:            "Error",
+		StatusPollerType:     "StatusPoller",
+		WaitType:             "Wait",
+		ClusterctlType:       "Clusterctl",
+		IsogenType:           "Isogen",
+		GenericContainerType: "GenericContainer",
+		BaremetalManagerType: "BaremetalManager",
+		ExecutorPluginType:   "ExecutorPlugin",
+		PhaseHookType:        "PhaseHook",
+		PhaseRetryType:       "PhaseRetry",
+		ApplyWaveType:        "ApplyWave",
+	}
+	waitOperations = map[WaitOperation]string{
+		WaitStart:   "Start",
+		WaitEnd:     "End",
+		WaitTimeout: "Timeout",
+	}
+	clusterctlOperations = map[ClusterctlOperation]string{
+		ClusterctlInitStart: "InitStart",
+		ClusterctlInitEnd:   "InitEnd",
+		ClusterctlMoveStart: "MoveStart",
+		ClusterctlMoveEnd:   "MoveEnd",
+	}
+	isogenOperations = map[IsogenOperation]string{
+		IsogenStart:      "Start",
+		IsogenValidation: "Validation",
+		IsogenEnd:        "End",
+	}
+	genericContainerOperations = map[GenericContainerOperation]string{
+		GenericContainerStart: "Start",
+		GenericContainerStop:  "Stop",
+	}
+	baremetalManagerOperations = map[BaremetalManagerOperation]string{
+		BaremetalManagerStart:    "Start",
+		BaremetalManagerComplete: "Complete",
+	}
+	executorPluginOperations = map[ExecutorPluginOperation]string{
+		ExecutorPluginStart:   "Start",
+		ExecutorPluginMessage: "Message",
+		ExecutorPluginEnd:     "End",
+	}
+	phaseHookOperations = map[PhaseHookOperation]string{
+		PhaseHookStart:   "Start",
+		PhaseHookEnd:     "End",
+		PhaseHookWarning: "Warning",
+	}
+	phaseRetryOperations = map[PhaseRetryOperation]string{
+		PhaseRetryAttemptStart:  "AttemptStart",
+		PhaseRetryAttemptFailed: "AttemptFailed",
+	}
+	applyWaveOperations = map[ApplyWaveOperation]string{
+		ApplyWaveStart: "Start",
+		ApplyWaveEnd:   "End",
+	}
+)
+
+// JSONProcessor is implementation of EventProcessor which writes every event
+// as a JSON object on a separate line, so that the output can be parsed by other programs
+type JSONProcessor struct {
+	encoder *json.Encoder
+}
+
+// NewJSONProcessor returns instance of JSONProcessor writing events to w
+func NewJSONProcessor(w io.Writer) EventProcessor {
+	return &JSONProcessor{encoder: json.NewEncoder(w)}
+}
+
+// Process is implementation of EventProcessor
+func (p *JSONProcessor) Process(ch <-chan Event) error {
+	errs := []error{}
+	for e := range ch {
+		if err := ErrorOf(e); err != nil {
+			errs = append(errs, err)
+		}
+		// channel must be read till the end even if output is broken, so that executor is not blocked
+		if err := p.encoder.Encode(ToJSONEvent(e)); err != nil {
+			log.Printf("Failed to write event: %v", err)
+		}
+	}
+	return checkErrors(errs)
+}
+
+// ToJSONEvent converts the event to its machine readable representation
+func ToJSONEvent(e Event) JSONEvent {
+	je := JSONEvent{
+		Timestamp: e.Timestamp,
+		Phase:     e.PhaseName,
+		Cluster:   e.ClusterName,
+		Type:      typeNames[e.Type],
+	}
+	if je.Timestamp.IsZero() {
+		je.Timestamp = time.Now()
+	}
+	if je.Type == "" {
+		je.Type = fmt.Sprintf("Unknown(%d)", e.Type)
+	}
+
+	switch e.Type {
+	case ApplierType:
+		setApplierEvent(&je, e.ApplierEvent)
+	case ErrorType:
+		je.Error = errorString(e.ErrorEvent.Error)
+	case StatusPollerType:
+		setStatusEvent(&je, e.StatusPollerEvent)
+	case WaitType:
+		je.Operation, je.Message = waitOperations[e.WaitEvent.Operation], e.WaitEvent.Message
+		je.Error = errorString(e.WaitEvent.Error)
+	case ClusterctlType:
+		je.Operation, je.Message = clusterctlOperations[e.ClusterctlEvent.Operation], e.ClusterctlEvent.Message
+	case IsogenType:
+		je.Operation, je.Message = isogenOperations[e.IsogenEvent.Operation], e.IsogenEvent.Message
+	case GenericContainerType:
+		je.Operation = genericContainerOperations[e.GenericContainerEvent.Operation]
+		je.Message = e.GenericContainerEvent.Message
+	case BaremetalManagerType:
+		je.Operation = baremetalManagerOperations[e.BaremetalManagerEvent.Operation]
+		je.Resource, je.Message = e.BaremetalManagerEvent.HostName, e.BaremetalManagerEvent.Message
+	case ExecutorPluginType:
+		je.Operation = executorPluginOperations[e.ExecutorPluginEvent.Operation]
+		je.Message = e.ExecutorPluginEvent.Message
+	case PhaseHookType:
+		je.Operation = phaseHookOperations[e.PhaseHookEvent.Operation]
+		je.Resource, je.Message = e.PhaseHookEvent.Hook, e.PhaseHookEvent.Message
+	case PhaseRetryType:
+		je.Operation, je.Message = phaseRetryOperations[e.PhaseRetryEvent.Operation], e.PhaseRetryEvent.Message
+	case ApplyWaveType:
+		je.Operation = applyWaveOperations[e.ApplyWaveEvent.Operation]
+		je.Message = fmt.Sprintf("wave %d: %s", e.ApplyWaveEvent.Wave, e.ApplyWaveEvent.Message)
+	}
+	return je
+}
+
+func setApplierEvent(je *JSONEvent, e applyevent.Event) {
+	switch e.Type {
+	case applyevent.InitType:
+		je.Operation = "Init"
+	case applyevent.ApplyType:
+		je.Operation = fmt.Sprint(e.ApplyEvent.Operation)
+		if e.ApplyEvent.Type == applyevent.ApplyEventCompleted {
+			je.Operation, je.Message = "Completed", "apply is completed"
+		}
+		je.Resource = objectName(e.ApplyEvent.Object)
+	case applyevent.StatusType:
+		setStatusEvent(je, e.StatusEvent)
+	case applyevent.PruneType:
+		je.Operation = fmt.Sprint(e.PruneEvent.Operation)
+		je.Resource = objectName(e.PruneEvent.Object)
+	case applyevent.DeleteType:
+		je.Operation = fmt.Sprint(e.DeleteEvent.Operation)
+		je.Resource = objectName(e.DeleteEvent.Object)
+	case applyevent.ErrorType:
+		je.Error = errorString(e.ErrorEvent.Err)
+	}
+}
+
+func setStatusEvent(je *JSONEvent, e statuspollerevent.Event) {
+	je.Operation = fmt.Sprint(e.EventType)
+	je.Error = errorString(e.Error)
+	if res := e.Resource; res != nil {
+		je.Resource = objMetadataName(res.Identifier)
+		je.Message = fmt.Sprintf("%s: %s", res.Status, res.Message)
+		if res.Error != nil {
+			je.Error = res.Error.Error()
+		}
+	}
+}
+
+// objectName returns kubernetes object name in Kind/namespace/name format
+func objectName(obj runtime.Object) string {
+	if obj == nil {
+		return ""
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if accessor.GetNamespace() == "" {
+		return fmt.Sprintf("%s/%s", kind, accessor.GetName())
+	}
+	return fmt.Sprintf("%s/%s/%s", kind, accessor.GetNamespace(), accessor.GetName())
+}
+
+func objMetadataName(id object.ObjMetadata) string {
+	if id.Namespace == "" {
+		return fmt.Sprintf("%s/%s", id.GroupKind.Kind, id.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", id.GroupKind.Kind, id.Namespace, id.Name)
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,163 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/events"
+)
+
+func TestJSONProcessor(t *testing.T) {
+	ts := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		events    []events.Event
+		expected  []events.JSONEvent
+		errString string
+	}{
+		{
+			name: "clusterctl and isogen events",
+			events: []events.Event{
+				{
+					Type:        events.ClusterctlType,
+					Timestamp:   ts,
+					PhaseName:   "clusterctl-init",
+					ClusterName: "ephemeral-cluster",
+					ClusterctlEvent: events.ClusterctlEvent{
+						Operation: events.ClusterctlInitStart,
+						Message:   "starting clusterctl init",
+					},
+				},
+				{
+					Type:      events.IsogenType,
+					Timestamp: ts,
+					PhaseName: "bootstrap-iso",
+					IsogenEvent: events.IsogenEvent{
+						Operation: events.IsogenEnd,
+						Message:   "iso generation is complete",
+					},
+				},
+			},
+			expected: []events.JSONEvent{
+				{
+					Timestamp: ts,
+					Phase:     "clusterctl-init",
+					Cluster:   "ephemeral-cluster",
+					Type:      "Clusterctl",
+					Operation: "InitStart",
+					Message:   "starting clusterctl init",
+				},
+				{
+					Timestamp: ts,
+					Phase:     "bootstrap-iso",
+					Type:      "Isogen",
+					Operation: "End",
+					Message:   "iso generation is complete",
+				},
+			},
+		},
+		{
+			name: "error event",
+			events: []events.Event{
+				{
+					Type:       events.ErrorType,
+					Timestamp:  ts,
+					PhaseName:  "initinfra",
+					ErrorEvent: events.ErrorEvent{Error: fmt.Errorf("somerror")},
+				},
+			},
+			expected: []events.JSONEvent{
+				{
+					Timestamp: ts,
+					Phase:     "initinfra",
+					Type:      "Error",
+					Error:     "somerror",
+				},
+			},
+			errString: "somerror",
+		},
+		{
+			name: "apply wave event",
+			events: []events.Event{
+				{
+					Type:      events.ApplyWaveType,
+					Timestamp: ts,
+					PhaseName: "initinfra",
+					ApplyWaveEvent: events.ApplyWaveEvent{
+						Operation: events.ApplyWaveStart,
+						Wave:      1,
+						Message:   "applying 2 resources",
+					},
+				},
+			},
+			expected: []events.JSONEvent{
+				{
+					Timestamp: ts,
+					Phase:     "initinfra",
+					Type:      "ApplyWave",
+					Operation: "Start",
+					Message:   "wave 1: applying 2 resources",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			ch := make(chan events.Event, len(tt.events))
+			for _, e := range tt.events {
+				ch <- e
+			}
+			close(ch)
+			err := events.NewJSONProcessor(buf).Process(ch)
+			if tt.errString != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errString)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			decoder := json.NewDecoder(buf)
+			actual := []events.JSONEvent{}
+			for decoder.More() {
+				je := events.JSONEvent{}
+				require.NoError(t, decoder.Decode(&je))
+				actual = append(actual, je)
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestToJSONEventApplier(t *testing.T) {
+	for _, e := range append(successEvents(), errApplyEvents()...) {
+		je := events.ToJSONEvent(e)
+		assert.Equal(t, "Applier", je.Type)
+		assert.False(t, je.Timestamp.IsZero())
+	}
+
+	errEvent := events.ToJSONEvent(errApplyEvents()[0])
+	assert.Contains(t, errEvent.Error, "apply-error")
+}
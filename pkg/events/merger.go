@@ -0,0 +1,65 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+// Merger merges event streams of several executors running simultaneously into
+// a single stream, which is processed by one EventProcessor
+type Merger struct {
+	out    chan Event
+	result chan error
+}
+
+// NewMerger starts processing of the merged event stream with the given processor
+func NewMerger(processor EventProcessor) *Merger {
+	m := &Merger{
+		out:    make(chan Event),
+		result: make(chan error, 1),
+	}
+	go func() {
+		m.result <- processor.Process(m.out)
+	}()
+	return m
+}
+
+// Processor returns an EventProcessor that forwards events into the merged stream.
+// Its Process method returns an error only if error events were received on the
+// forwarded stream, so that each stream can be checked independently
+func (m *Merger) Processor() EventProcessor {
+	return &forwardProcessor{out: m.out}
+}
+
+// Close finishes the merged stream and returns the result of the underlying processor,
+// it must be called only after all forwarding processors have returned
+func (m *Merger) Close() error {
+	close(m.out)
+	return <-m.result
+}
+
+// forwardProcessor is an implementation of EventProcessor used by Merger
+type forwardProcessor struct {
+	out chan<- Event
+}
+
+// Process is implementation of EventProcessor
+func (p *forwardProcessor) Process(ch <-chan Event) error {
+	errs := []error{}
+	for e := range ch {
+		if err := ErrorOf(e); err != nil {
+			errs = append(errs, err)
+		}
+		p.out <- e
+	}
+	return checkErrors(errs)
+}
@@ -0,0 +1,72 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/events"
+)
+
+type countingProcessor struct {
+	count int
+}
+
+func (p *countingProcessor) Process(ch <-chan events.Event) error {
+	for range ch {
+		p.count++
+	}
+	return nil
+}
+
+func TestMerger(t *testing.T) {
+	underlying := &countingProcessor{}
+	merger := events.NewMerger(underlying)
+
+	streams := [][]events.Event{
+		successEvents(),
+		errEvents(),
+		successEvents(),
+	}
+	expectedCount := 0
+	procErrs := make([]error, len(streams))
+	wg := sync.WaitGroup{}
+	for i, stream := range streams {
+		expectedCount += len(stream)
+		ch := make(chan events.Event, len(stream))
+		for _, e := range stream {
+			ch <- e
+		}
+		close(ch)
+		wg.Add(1)
+		go func(i int, ch <-chan events.Event) {
+			defer wg.Done()
+			procErrs[i] = merger.Processor().Process(ch)
+		}(i, ch)
+	}
+	wg.Wait()
+
+	require.NoError(t, merger.Close())
+	assert.Equal(t, expectedCount, underlying.count)
+	assert.NoError(t, procErrs[0])
+	require.Error(t, procErrs[1])
+	assert.Contains(t, procErrs[1].Error(), fmt.Sprintf("%v", "somerror"))
+	assert.NoError(t, procErrs[2])
+}
@@ -15,11 +15,16 @@
 package events
 
 import (
+	"fmt"
+	"io"
+
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"sigs.k8s.io/cli-utils/cmd/printers"
 	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
 
 	applyevent "sigs.k8s.io/cli-utils/pkg/apply/event"
+	statuspollerevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 
 	"opendev.org/airship/airshipctl/pkg/log"
 )
@@ -33,16 +38,56 @@ type EventProcessor interface {
 type DefaultProcessor struct {
 	errors      []error
 	applierChan chan<- applyevent.Event
+	// printerDone is closed when the printer has printed all events sent to applierChan
+	printerDone <-chan struct{}
+	streams     genericclioptions.IOStreams
+	out         io.Writer
+	// statuses holds the last printed status of every polled resource, so that
+	// a line is printed only when status of the resource changes
+	statuses map[resourceKey]resourceStatus
+}
+
+// resourceKey identifies polled resource, phases executed simultaneously may wait for the same resource
+type resourceKey struct {
+	phase string
+	id    object.ObjMetadata
+}
+
+type resourceStatus struct {
+	status  string
+	message string
 }
 
 // NewDefaultProcessor returns instance of DefaultProcessor as interface Implementation
 func NewDefaultProcessor(streams genericclioptions.IOStreams) EventProcessor {
-	applyCh := make(chan applyevent.Event)
-	go printers.GetPrinter(printers.EventsPrinter, streams).Print(applyCh, common.DryRunNone)
-	return &DefaultProcessor{
-		errors:      []error{},
-		applierChan: applyCh,
+	p := &DefaultProcessor{
+		errors:   []error{},
+		streams:  streams,
+		out:      streams.Out,
+		statuses: make(map[resourceKey]resourceStatus),
 	}
+	p.startPrinter()
+	return p
+}
+
+// startPrinter starts printing of applier events in a separate goroutine
+func (p *DefaultProcessor) startPrinter() {
+	applyCh := make(chan applyevent.Event)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		printers.GetPrinter(printers.EventsPrinter, p.streams).Print(applyCh, common.DryRunNone)
+	}()
+	p.applierChan = applyCh
+	p.printerDone = done
+}
+
+// flushPrinter waits until applier events received so far are printed and starts a new printer,
+// so that lines written directly to the output follow them in the order of events
+func (p *DefaultProcessor) flushPrinter() {
+	close(p.applierChan)
+	<-p.printerDone
+	p.startPrinter()
 }
 
 // Process is implementation of EventProcessor
@@ -54,19 +99,23 @@ func (p *DefaultProcessor) Process(ch <-chan Event) error {
 		case ErrorType:
 			log.Printf("Received error on event channel %v", e.ErrorEvent)
 			p.errors = append(p.errors, e.ErrorEvent.Error)
-		case ClusterctlType, IsogenType:
+		case StatusPollerType:
+			p.processStatusPollerEvent(e.PhaseName, e.StatusPollerEvent)
+		case WaitType:
+			p.processWaitEvent(e.PhaseName, e.WaitEvent)
+		case ApplyWaveType:
+			p.processApplyWaveEvent(e.ApplyWaveEvent)
+		case ClusterctlType, IsogenType, GenericContainerType, BaremetalManagerType, ExecutorPluginType,
+			PhaseHookType, PhaseRetryType:
 			// TODO each event needs to be interface that allows us to print it for example
 			// Stringer interface or AsYAML for further processing.
 			// For now we print the event object as is
 			log.Printf("Received event: %v", e)
-		case StatusPollerType:
-			log.Fatalf("Processing for status poller events are not yet implemented")
-		case WaitType:
-			log.Fatalf("Processing for wait events are not yet implemented")
 		default:
 			log.Fatalf("Unknown event type received: %d", e.Type)
 		}
 	}
+	p.flushPrinter()
 	return checkErrors(p.errors)
 }
 
@@ -80,6 +129,68 @@ func (p *DefaultProcessor) processApplierEvent(e applyevent.Event) {
 	p.applierChan <- e
 }
 
+// processStatusPollerEvent prints a line for every resource which status or status message has changed
+func (p *DefaultProcessor) processStatusPollerEvent(phase string, e statuspollerevent.Event) {
+	switch e.EventType {
+	case statuspollerevent.ErrorEvent:
+		log.Printf("Received error when polling status of resources %v", e.Error)
+		p.errors = append(p.errors, e.Error)
+	case statuspollerevent.ResourceUpdateEvent:
+		if e.Resource == nil {
+			return
+		}
+		key := resourceKey{phase: phase, id: e.Resource.Identifier}
+		current := resourceStatus{status: string(e.Resource.Status), message: e.Resource.Message}
+		if e.Resource.Error != nil {
+			current.message = e.Resource.Error.Error()
+		}
+		if last, printed := p.statuses[key]; printed && last == current {
+			return
+		}
+		p.statuses[key] = current
+		line := fmt.Sprintf("%s is %s", objMetadataName(e.Resource.Identifier), current.status)
+		if current.message != "" {
+			line = fmt.Sprintf("%s: %s", line, current.message)
+		}
+		fmt.Fprintln(p.out, line)
+	}
+}
+
+// processWaitEvent prints progress of waiting for resources, timeout is reported as an error
+func (p *DefaultProcessor) processWaitEvent(phase string, e WaitEvent) {
+	switch e.Operation {
+	case WaitStart:
+		fmt.Fprintf(p.out, "Started waiting: %s\n", e.Message)
+	case WaitEnd:
+		fmt.Fprintf(p.out, "Finished waiting: %s\n", e.Message)
+		p.forgetStatuses(phase)
+	case WaitTimeout:
+		log.Printf("Timed out waiting for resources: %s", e.Message)
+		p.errors = append(p.errors, e.Error)
+		p.forgetStatuses(phase)
+	}
+}
+
+// processApplyWaveEvent prints boundaries of the apply waves after applier events of the previous wave
+func (p *DefaultProcessor) processApplyWaveEvent(e ApplyWaveEvent) {
+	p.flushPrinter()
+	switch e.Operation {
+	case ApplyWaveStart:
+		fmt.Fprintf(p.out, "Started apply wave %d: %s\n", e.Wave, e.Message)
+	case ApplyWaveEnd:
+		fmt.Fprintf(p.out, "Finished apply wave %d: %s\n", e.Wave, e.Message)
+	}
+}
+
+// forgetStatuses removes statuses printed for the phase, so that they are printed again if the phase is retried
+func (p *DefaultProcessor) forgetStatuses(phase string) {
+	for key := range p.statuses {
+		if key.phase == phase {
+			delete(p.statuses, key)
+		}
+	}
+}
+
 // Check list of errors, and verify that these errors we are able to tolerate
 // currently we simply check if the list is empty or not
 func checkErrors(errs []error) error {
@@ -16,10 +16,16 @@ package events_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	statuspollerevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
 
 	"opendev.org/airship/airshipctl/pkg/events"
 	"opendev.org/airship/airshipctl/pkg/k8s/utils"
@@ -102,3 +108,145 @@ func errApplyEvents() []events.Event {
 	}
 	return airEvents
 }
+
+func TestDefaultProcessorApplyWaveOrder(t *testing.T) {
+	waveEvent := func(op events.ApplyWaveOperation, message string) events.Event {
+		return events.Event{
+			Type:           events.ApplyWaveType,
+			ApplyWaveEvent: events.ApplyWaveEvent{Operation: op, Wave: 1, Message: message},
+		}
+	}
+	evts := []events.Event{waveEvent(events.ApplyWaveStart, "applying 1 resources")}
+	evts = append(evts, successEvents()...)
+	evts = append(evts, waveEvent(events.ApplyWaveEnd, "1 resources are applied"))
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	ch := make(chan events.Event, len(evts))
+	for _, e := range evts {
+		ch <- e
+	}
+	close(ch)
+	require.NoError(t, events.NewDefaultProcessor(streams).Process(ch))
+	// applier events of the wave must be printed between its boundaries
+	output := out.String()
+	assert.True(t, strings.HasPrefix(output, "Started apply wave 1: applying 1 resources\n"), output)
+	assert.True(t, strings.HasSuffix(output, "Finished apply wave 1: 1 resources are applied\n"), output)
+	assert.Contains(t, output, "airshipit-inventoryID")
+}
+
+func TestDefaultProcessorWait(t *testing.T) {
+	id := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Namespace: "default",
+		Name:      "test",
+	}
+	update := func(st status.Status, message string) events.Event {
+		return events.Event{
+			Type:      events.StatusPollerType,
+			PhaseName: "wait",
+			StatusPollerEvent: statuspollerevent.Event{
+				EventType: statuspollerevent.ResourceUpdateEvent,
+				Resource: &statuspollerevent.ResourceStatus{
+					Identifier: id,
+					Status:     st,
+					Message:    message,
+				},
+			},
+		}
+	}
+	waitEvent := func(op events.WaitOperation, message string, err error) events.Event {
+		return events.Event{
+			Type:      events.WaitType,
+			PhaseName: "wait",
+			WaitEvent: events.WaitEvent{Operation: op, Message: message, Error: err},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		events         []events.Event
+		expectedOutput string
+		errString      string
+	}{
+		{
+			name: "resource becomes current",
+			events: []events.Event{
+				waitEvent(events.WaitStart, "waiting for 1 resources to become Current", nil),
+				update(status.InProgressStatus, "Replicas: 0/1"),
+				update(status.InProgressStatus, "Replicas: 0/1"),
+				update(status.CurrentStatus, ""),
+				waitEvent(events.WaitEnd, "all resources are Current", nil),
+			},
+			expectedOutput: `Started waiting: waiting for 1 resources to become Current
+Deployment/default/test is InProgress: Replicas: 0/1
+Deployment/default/test is Current
+Finished waiting: all resources are Current
+`,
+		},
+		{
+			name: "status poller error",
+			events: []events.Event{
+				{
+					Type: events.StatusPollerType,
+					StatusPollerEvent: statuspollerevent.Event{
+						EventType: statuspollerevent.ErrorEvent,
+						Error:     fmt.Errorf("poll-error"),
+					},
+				},
+			},
+			errString: "poll-error",
+		},
+		{
+			name: "wait timeout",
+			events: []events.Event{
+				update(status.InProgressStatus, ""),
+				waitEvent(events.WaitTimeout, "1 resources haven't become Current", fmt.Errorf("wait-timeout")),
+			},
+			expectedOutput: "Deployment/default/test is InProgress\n",
+			errString:      "wait-timeout",
+		},
+		{
+			name: "apply waves",
+			events: []events.Event{
+				{
+					Type: events.ApplyWaveType,
+					ApplyWaveEvent: events.ApplyWaveEvent{
+						Operation: events.ApplyWaveStart,
+						Wave:      -1,
+						Message:   "applying 1 resources",
+					},
+				},
+				{
+					Type: events.ApplyWaveType,
+					ApplyWaveEvent: events.ApplyWaveEvent{
+						Operation: events.ApplyWaveEnd,
+						Wave:      -1,
+						Message:   "resources are applied",
+					},
+				},
+			},
+			expectedOutput: "Started apply wave -1: applying 1 resources\nFinished apply wave -1: resources are applied\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			ch := make(chan events.Event, len(tt.events))
+			for _, e := range tt.events {
+				ch <- e
+			}
+			close(ch)
+			err := events.NewDefaultProcessor(streams).Process(ch)
+			if tt.errString != "" {
+				require.Error(t, err)
+				assert.IsType(t, events.ErrEventReceived{}, err)
+				assert.Contains(t, err.Error(), tt.errString)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedOutput, out.String())
+		})
+	}
+}
@@ -0,0 +1,350 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	applyevent "sigs.k8s.io/cli-utils/pkg/apply/event"
+	statuspollerevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+// recordedEvent is a serializable form of Event, events file contains one recorded event per line.
+// Only one of the event fields is set according to the event type. Errors are saved as messages
+// and status poller events don't keep polled objects, everything else is restored as is
+type recordedEvent struct {
+	Type             string                 `json:"type"`
+	Timestamp        time.Time              `json:"timestamp"`
+	Phase            string                 `json:"phase,omitempty"`
+	Cluster          string                 `json:"cluster,omitempty"`
+	Error            string                 `json:"error,omitempty"`
+	Applier          *recordedApplierEvent  `json:"applier,omitempty"`
+	StatusPoller     *recordedStatusEvent   `json:"statusPoller,omitempty"`
+	Wait             *recordedWaitEvent     `json:"wait,omitempty"`
+	Clusterctl       *ClusterctlEvent       `json:"clusterctl,omitempty"`
+	Isogen           *IsogenEvent           `json:"isogen,omitempty"`
+	GenericContainer *GenericContainerEvent `json:"genericContainer,omitempty"`
+	BaremetalManager *BaremetalManagerEvent `json:"baremetalManager,omitempty"`
+	ExecutorPlugin   *ExecutorPluginEvent   `json:"executorPlugin,omitempty"`
+	PhaseHook        *PhaseHookEvent        `json:"phaseHook,omitempty"`
+	PhaseRetry       *PhaseRetryEvent       `json:"phaseRetry,omitempty"`
+	ApplyWave        *ApplyWaveEvent        `json:"applyWave,omitempty"`
+}
+
+type recordedApplierEvent struct {
+	Type   applyevent.Type       `json:"type"`
+	Error  string                `json:"error,omitempty"`
+	Init   *applyevent.InitEvent `json:"init,omitempty"`
+	Apply  *recordedOperation    `json:"apply,omitempty"`
+	Status *recordedStatusEvent  `json:"status,omitempty"`
+	Prune  *recordedOperation    `json:"prune,omitempty"`
+	Delete *recordedOperation    `json:"delete,omitempty"`
+}
+
+// recordedOperation holds apply, prune or delete event of the applier
+type recordedOperation struct {
+	Type      int             `json:"type"`
+	Operation int             `json:"operation"`
+	Object    json.RawMessage `json:"object,omitempty"`
+}
+
+type recordedStatusEvent struct {
+	EventType  statuspollerevent.EventType `json:"eventType"`
+	Error      string                      `json:"error,omitempty"`
+	Identifier *object.ObjMetadata         `json:"identifier,omitempty"`
+	Status     status.Status               `json:"status,omitempty"`
+	Message    string                      `json:"message,omitempty"`
+	// ResourceError is an error encountered when status of the resource was computed
+	ResourceError string `json:"resourceError,omitempty"`
+}
+
+type recordedWaitEvent struct {
+	Operation WaitOperation `json:"operation"`
+	Message   string        `json:"message,omitempty"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// Recorder is implementation of EventProcessor which writes every event to the events file
+// before passing it to the wrapped processor, recorded events can be processed again by Replay
+type Recorder struct {
+	encoder   *json.Encoder
+	processor EventProcessor
+}
+
+// NewRecorder returns instance of Recorder writing events to w
+func NewRecorder(w io.Writer, processor EventProcessor) EventProcessor {
+	return &Recorder{encoder: json.NewEncoder(w), processor: processor}
+}
+
+// Process is implementation of EventProcessor
+func (r *Recorder) Process(ch <-chan Event) error {
+	out := make(chan Event)
+	go func() {
+		defer close(out)
+		for e := range ch {
+			// failure to record the event must not affect the phase run
+			if err := r.encoder.Encode(recordEvent(e)); err != nil {
+				log.Printf("Failed to record event: %v", err)
+			}
+			out <- e
+		}
+	}()
+	return r.processor.Process(out)
+}
+
+// Replay reads events written by Recorder and passes them to the processor. Error is returned
+// if the events file is malformed or the processor has received error events
+func Replay(r io.Reader, processor EventProcessor) error {
+	ch := make(chan Event)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		decoder := json.NewDecoder(r)
+		for i := 1; ; i++ {
+			rec := recordedEvent{}
+			err := decoder.Decode(&rec)
+			if err == io.EOF {
+				readErr <- nil
+				return
+			}
+			if err != nil {
+				readErr <- ErrInvalidEventsFile{Event: i, Err: err}
+				return
+			}
+			e, err := rec.event()
+			if err != nil {
+				readErr <- ErrInvalidEventsFile{Event: i, Err: err}
+				return
+			}
+			ch <- e
+		}
+	}()
+	procErr := processor.Process(ch)
+	if err := <-readErr; err != nil {
+		return err
+	}
+	return procErr
+}
+
+func recordEvent(e Event) recordedEvent {
+	rec := recordedEvent{
+		Type:      typeNames[e.Type],
+		Timestamp: e.Timestamp,
+		Phase:     e.PhaseName,
+		Cluster:   e.ClusterName,
+	}
+	switch e.Type {
+	case ApplierType:
+		rec.Applier = recordApplierEvent(e.ApplierEvent)
+	case ErrorType:
+		rec.Error = errorString(e.ErrorEvent.Error)
+	case StatusPollerType:
+		rec.StatusPoller = recordStatusEvent(e.StatusPollerEvent)
+	case WaitType:
+		rec.Wait = &recordedWaitEvent{
+			Operation: e.WaitEvent.Operation,
+			Message:   e.WaitEvent.Message,
+			Error:     errorString(e.WaitEvent.Error),
+		}
+	case ClusterctlType:
+		rec.Clusterctl = &e.ClusterctlEvent
+	case IsogenType:
+		rec.Isogen = &e.IsogenEvent
+	case GenericContainerType:
+		rec.GenericContainer = &e.GenericContainerEvent
+	case BaremetalManagerType:
+		rec.BaremetalManager = &e.BaremetalManagerEvent
+	case ExecutorPluginType:
+		rec.ExecutorPlugin = &e.ExecutorPluginEvent
+	case PhaseHookType:
+		rec.PhaseHook = &e.PhaseHookEvent
+	case PhaseRetryType:
+		rec.PhaseRetry = &e.PhaseRetryEvent
+	case ApplyWaveType:
+		rec.ApplyWave = &e.ApplyWaveEvent
+	}
+	return rec
+}
+
+func recordApplierEvent(e applyevent.Event) *recordedApplierEvent {
+	rec := &recordedApplierEvent{Type: e.Type}
+	switch e.Type {
+	case applyevent.InitType:
+		rec.Init = &e.InitEvent
+	case applyevent.ErrorType:
+		rec.Error = errorString(e.ErrorEvent.Err)
+	case applyevent.ApplyType:
+		rec.Apply = recordOperation(int(e.ApplyEvent.Type), int(e.ApplyEvent.Operation), e.ApplyEvent.Object)
+	case applyevent.StatusType:
+		rec.Status = recordStatusEvent(e.StatusEvent)
+	case applyevent.PruneType:
+		rec.Prune = recordOperation(int(e.PruneEvent.Type), int(e.PruneEvent.Operation), e.PruneEvent.Object)
+	case applyevent.DeleteType:
+		rec.Delete = recordOperation(int(e.DeleteEvent.Type), int(e.DeleteEvent.Operation), e.DeleteEvent.Object)
+	}
+	return rec
+}
+
+func recordOperation(eventType, operation int, obj runtime.Object) *recordedOperation {
+	rec := &recordedOperation{Type: eventType, Operation: operation}
+	if obj == nil {
+		return rec
+	}
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		log.Printf("Failed to record object %s: %v", objectName(obj), err)
+		return rec
+	}
+	rec.Object = raw
+	return rec
+}
+
+func recordStatusEvent(e statuspollerevent.Event) *recordedStatusEvent {
+	rec := &recordedStatusEvent{EventType: e.EventType, Error: errorString(e.Error)}
+	if res := e.Resource; res != nil {
+		id := res.Identifier
+		rec.Identifier = &id
+		rec.Status = res.Status
+		rec.Message = res.Message
+		rec.ResourceError = errorString(res.Error)
+	}
+	return rec
+}
+
+// event restores the event from its recorded form
+func (rec recordedEvent) event() (Event, error) {
+	e := Event{
+		Timestamp:   rec.Timestamp,
+		PhaseName:   rec.Phase,
+		ClusterName: rec.Cluster,
+	}
+	var known bool
+	if e.Type, known = typeByName(rec.Type); !known {
+		return e, ErrUnknownEventType{Type: rec.Type}
+	}
+
+	var err error
+	switch {
+	case rec.Applier != nil:
+		e.ApplierEvent, err = rec.Applier.event()
+	case rec.StatusPoller != nil:
+		e.StatusPollerEvent = rec.StatusPoller.event()
+	case rec.Wait != nil:
+		e.WaitEvent = WaitEvent{
+			Operation: rec.Wait.Operation,
+			Message:   rec.Wait.Message,
+			Error:     replayedError(rec.Wait.Error),
+		}
+	case rec.Clusterctl != nil:
+		e.ClusterctlEvent = *rec.Clusterctl
+	case rec.Isogen != nil:
+		e.IsogenEvent = *rec.Isogen
+	case rec.GenericContainer != nil:
+		e.GenericContainerEvent = *rec.GenericContainer
+	case rec.BaremetalManager != nil:
+		e.BaremetalManagerEvent = *rec.BaremetalManager
+	case rec.ExecutorPlugin != nil:
+		e.ExecutorPluginEvent = *rec.ExecutorPlugin
+	case rec.PhaseHook != nil:
+		e.PhaseHookEvent = *rec.PhaseHook
+	case rec.PhaseRetry != nil:
+		e.PhaseRetryEvent = *rec.PhaseRetry
+	case rec.ApplyWave != nil:
+		e.ApplyWaveEvent = *rec.ApplyWave
+	}
+	if e.Type == ErrorType {
+		e.ErrorEvent = ErrorEvent{Error: replayedError(rec.Error)}
+	}
+	return e, err
+}
+
+func (rec recordedApplierEvent) event() (applyevent.Event, error) {
+	e := applyevent.Event{Type: rec.Type}
+	var err error
+	switch {
+	case rec.Type == applyevent.ErrorType:
+		e.ErrorEvent = applyevent.ErrorEvent{Err: replayedError(rec.Error)}
+	case rec.Init != nil:
+		e.InitEvent = *rec.Init
+	case rec.Status != nil:
+		e.StatusEvent = rec.Status.event()
+	case rec.Apply != nil:
+		e.ApplyEvent.Type = applyevent.ApplyEventType(rec.Apply.Type)
+		e.ApplyEvent.Operation = applyevent.ApplyEventOperation(rec.Apply.Operation)
+		e.ApplyEvent.Object, err = rec.Apply.object()
+	case rec.Prune != nil:
+		e.PruneEvent.Type = applyevent.PruneEventType(rec.Prune.Type)
+		e.PruneEvent.Operation = applyevent.PruneEventOperation(rec.Prune.Operation)
+		e.PruneEvent.Object, err = rec.Prune.object()
+	case rec.Delete != nil:
+		e.DeleteEvent.Type = applyevent.DeleteEventType(rec.Delete.Type)
+		e.DeleteEvent.Operation = applyevent.DeleteEventOperation(rec.Delete.Operation)
+		e.DeleteEvent.Object, err = rec.Delete.object()
+	}
+	return e, err
+}
+
+// object restores kubernetes object of the operation as unstructured
+func (rec recordedOperation) object() (runtime.Object, error) {
+	if len(rec.Object) == 0 {
+		return nil, nil
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(rec.Object); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (rec recordedStatusEvent) event() statuspollerevent.Event {
+	e := statuspollerevent.Event{
+		EventType: rec.EventType,
+		Error:     replayedError(rec.Error),
+	}
+	if rec.Identifier != nil {
+		e.Resource = &statuspollerevent.ResourceStatus{
+			Identifier: *rec.Identifier,
+			Status:     rec.Status,
+			Message:    rec.Message,
+			Error:      replayedError(rec.ResourceError),
+		}
+	}
+	return e
+}
+
+func typeByName(name string) (Type, bool) {
+	for t, n := range typeNames {
+		if n == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+// replayedError returns nil if there was no error when the event was recorded
+func replayedError(message string) error {
+	if message == "" {
+		return nil
+	}
+	return ErrReplayed{Message: message}
+}
@@ -0,0 +1,130 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/events"
+)
+
+// collectingProcessor saves received events and returns errors like other processors
+type collectingProcessor struct {
+	events []events.Event
+}
+
+func (p *collectingProcessor) Process(ch <-chan events.Event) error {
+	errs := []error{}
+	for e := range ch {
+		p.events = append(p.events, e)
+		if err := events.ErrorOf(e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return events.ErrEventReceived{Errors: errs}
+	}
+	return nil
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	ts := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	recorded := successEvents()
+	recorded = append(recorded, errApplyEvents()...)
+	recorded = append(recorded, errEvents()...)
+	recorded = append(recorded,
+		events.Event{
+			Type:            events.ClusterctlType,
+			ClusterctlEvent: events.ClusterctlEvent{Operation: events.ClusterctlMoveEnd, Message: "moved"},
+		},
+		events.Event{
+			Type: events.WaitType,
+			WaitEvent: events.WaitEvent{
+				Operation: events.WaitTimeout,
+				Message:   "1 resources haven't become Current",
+				Error:     fmt.Errorf("wait-timeout"),
+			},
+		},
+		events.Event{
+			Type:            events.PhaseRetryType,
+			PhaseRetryEvent: events.PhaseRetryEvent{Operation: events.PhaseRetryAttemptStart, Attempt: 1, MaxAttempts: 3},
+		},
+		events.Event{
+			Type:           events.ApplyWaveType,
+			ApplyWaveEvent: events.ApplyWaveEvent{Operation: events.ApplyWaveEnd, Wave: -1, Message: "applied"},
+		})
+	for i := range recorded {
+		recorded[i].Timestamp = ts
+		recorded[i].PhaseName = "initinfra"
+		recorded[i].ClusterName = "ephemeral-cluster"
+	}
+
+	buf := &bytes.Buffer{}
+	ch := make(chan events.Event, len(recorded))
+	for _, e := range recorded {
+		ch <- e
+	}
+	close(ch)
+	underlying := &collectingProcessor{}
+	err := events.NewRecorder(buf, underlying).Process(ch)
+	require.Error(t, err)
+	assert.Equal(t, recorded, underlying.events)
+	assert.Len(t, strings.Split(strings.TrimSpace(buf.String()), "\n"), len(recorded))
+
+	replayed := &collectingProcessor{}
+	err = events.Replay(buf, replayed)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "apply-error")
+	assert.Contains(t, err.Error(), "wait-timeout")
+	require.Len(t, replayed.events, len(recorded))
+	for i := range recorded {
+		assert.Equal(t, events.ToJSONEvent(recorded[i]), events.ToJSONEvent(replayed.events[i]))
+	}
+}
+
+func TestReplayInvalidFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		errString string
+	}{
+		{
+			name:      "malformed json",
+			content:   `{"type": "Clusterctl", "timestamp": "2021-01-01T00:00:00Z"}` + "\n{",
+			errString: "failed to read event 2 from events file",
+		},
+		{
+			name:      "unknown event type",
+			content:   `{"type": "Unknown", "timestamp": "2021-01-01T00:00:00Z"}`,
+			errString: `unknown event type "Unknown"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := events.Replay(strings.NewReader(tt.content), &collectingProcessor{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errString)
+		})
+	}
+}
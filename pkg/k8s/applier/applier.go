@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -43,6 +44,9 @@ import (
 const (
 	// DefaultNamespace to store inventory objects in
 	DefaultNamespace = "airshipit"
+	// DefaultFieldManager is a name of the field manager used for server-side apply
+	// and diff if it's not set in KubernetesApply document
+	DefaultFieldManager = "airshipctl"
 )
 
 // Applier delivers documents to kubernetes in a declarative way
@@ -71,41 +75,44 @@ func NewApplier(eventCh chan events.Event, f cmdutil.Factory, streams genericcli
 	}
 }
 
-// ApplyBundle apply bundle to kubernetes cluster
-func (a *Applier) ApplyBundle(bundle document.Bundle, ao ApplyOptions) {
+// ApplyBundle apply bundle to kubernetes cluster, apply is stopped when the context is cancelled
+func (a *Applier) ApplyBundle(ctx context.Context, bundle document.Bundle, ao ApplyOptions) {
 	defer close(a.eventChannel)
 	log.Debugf("Getting infos for bundle, inventory id is %s", ao.BundleName)
-	infos, err := a.getInfos(ao.BundleName, bundle)
+	infos, err := a.getInfos(ao, bundle)
 	if err != nil {
 		handleError(a.eventChannel, err)
 		return
 	}
 
-	ctx := context.Background()
-	ch := a.Driver.Run(ctx, infos, cliApplyOptions(ao))
-	for e := range ch {
-		a.eventChannel <- events.Event{
-			Type:         events.ApplierType,
-			ApplierEvent: e,
+	inventory, waves, err := splitWaves(infos)
+	if err != nil {
+		handleError(a.eventChannel, err)
+		return
+	}
+	if len(waves) > 1 {
+		if ao.WaitTimeout == 0 {
+			handleError(a.eventChannel, ErrWavesWithoutTimeout{Waves: len(waves)})
+			return
 		}
+		a.applyWaves(ctx, inventory, waves, ao)
+		return
 	}
+	a.run(ctx, infos, cliApplyOptions(ao))
 }
 
-func (a *Applier) getInfos(bundleName string, bundle document.Bundle) ([]*resource.Info, error) {
+func (a *Applier) getInfos(ao ApplyOptions, bundle document.Bundle) ([]*resource.Info, error) {
 	if bundle == nil {
 		return nil, ErrNilBundle{}
 	}
-	selector := document.
-		NewSelector().
-		ByLabel(clicommon.InventoryLabel).
-		ByKind(document.ConfigMapKind)
 	// if we could find exactly one inventory document, we don't do anything else with it
-	_, err := bundle.SelectOne(selector)
+	invDoc, err := bundle.SelectOne(inventorySelector())
 	// if we got an error, which means we could not find Config Map with inventory ID at rest
 	// now we need to generate and inject one at runtime
 	if err != nil && errors.As(err, &document.ErrDocNotFound{}) {
 		log.Debug("Inventory Object config Map not found, auto generating Inventory object")
-		invDoc, innerErr := NewInventoryDocument(bundleName)
+		var innerErr error
+		invDoc, innerErr = NewInventoryDocument(ao.BundleName, ao.Inventory)
 		if innerErr != nil {
 			// this should never happen
 			log.Debug("Failed to create new inventory document")
@@ -125,12 +132,23 @@ func (a *Applier) getInfos(bundleName string, bundle document.Bundle) ([]*resour
 	} else if err != nil {
 		return nil, err
 	}
-	if err = a.Driver.Initialize(a.Poller); err != nil {
+	if err = a.adoptInventory(invDoc, ao); err != nil {
+		return nil, err
+	}
+	if err = a.Driver.Initialize(a.Poller, ao.ServerSide); err != nil {
 		return nil, err
 	}
 	return a.ManifestReaderFactory(false, bundle, a.Factory).Read()
 }
 
+// inventorySelector selects inventory config map defined in the bundle
+func inventorySelector() document.Selector {
+	return document.
+		NewSelector().
+		ByLabel(clicommon.InventoryLabel).
+		ByKind(document.ConfigMapKind)
+}
+
 func (a *Applier) ensureNamespaceExists(name string) error {
 	clientSet, err := a.Factory.KubernetesClientSet()
 	if err != nil {
@@ -170,7 +188,7 @@ func cliApplyOptions(ao ApplyOptions) cliapply.Options {
 
 // Driver to cli-utils apply
 type Driver interface {
-	Initialize(p poller.Poller) error
+	Initialize(p poller.Poller, ssOpts ServerSideOptions) error
 	Run(ctx context.Context, infos []*resource.Info, options cliapply.Options) <-chan applyevent.Event
 }
 
@@ -180,8 +198,9 @@ type Adaptor struct {
 	Factory         cmdutil.Factory
 }
 
-// Initialize sets fake required command line flags for underlying cli-utils package
-func (a *Adaptor) Initialize(p poller.Poller) error {
+// Initialize sets fake required command line flags for underlying cli-utils package,
+// server-side apply is configured by setting values of the corresponding flags
+func (a *Adaptor) Initialize(p poller.Poller, ssOpts ServerSideOptions) error {
 	cmd := &cobra.Command{}
 	// Code below is copied from cli-utils package and used the same way as in upstream:
 	// https://github.com/kubernetes-sigs/cli-utils/blob/v0.14.0/cmd/apply/cmdapply.go#L35-L46
@@ -199,6 +218,18 @@ func (a *Adaptor) Initialize(p poller.Poller) error {
 	cmd.Flags().MarkHidden("server-side")     //nolint:errcheck
 	cmd.Flags().MarkHidden("force-conflicts") //nolint:errcheck
 	cmd.Flags().MarkHidden("field-manager")   //nolint:errcheck
+	if ssOpts.Enabled {
+		flagValues := map[string]string{
+			"server-side":     "true",
+			"force-conflicts": strconv.FormatBool(ssOpts.ForceConflicts),
+			"field-manager":   ssOpts.fieldManager(),
+		}
+		for name, value := range flagValues {
+			if err = cmd.Flags().Set(name, value); err != nil {
+				return err
+			}
+		}
+	}
 	err = a.CliUtilsApplier.Initialize(cmd)
 	if err != nil {
 		return err
@@ -215,23 +246,33 @@ func (a *Adaptor) Run(ctx context.Context, infos []*resource.Info, options cliap
 	return a.CliUtilsApplier.Run(ctx, infos, options)
 }
 
-// NewInventoryDocument returns default config map with inventory Id to group up the objects
-func NewInventoryDocument(inventoryID string) (document.Document, error) {
+// NewInventoryDocument returns config map with inventory Id to group up the objects, namespace, name
+// and labels of the config map are taken from inventory options, defaults are used if they're not set
+func NewInventoryDocument(inventoryID string, opts InventoryOptions) (document.Document, error) {
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	name := opts.Name
+	if name == "" {
+		name = fmt.Sprintf("%s-%s", "airshipit", inventoryID)
+	}
+	labels := map[string]string{}
+	for key, value := range opts.Labels {
+		labels[key] = value
+	}
+	labels[clicommon.InventoryLabel] = inventoryID
 	cm := v1.ConfigMap{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       document.ConfigMapKind,
 			APIVersion: document.ConfigMapVersion,
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			// name here is a dummy name, cli utils won't use this name, this config map simply parsed
-			// for inventory ID from label, and then ignores this config map
-			Name: fmt.Sprintf("%s-%s", "airshipit", inventoryID),
-			// TODO this limits us to single namespace. research passing this as parameter from
-			// somewhere higher level package that uses this module
-			Namespace: DefaultNamespace,
-			Labels: map[string]string{
-				clicommon.InventoryLabel: inventoryID,
-			},
+			// cli utils uses this config map as a template of the inventory object, the object is found
+			// in the namespace by inventory ID label, and its name is prefixed with the template name
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
 		},
 		Data: map[string]string{},
 	}
@@ -15,6 +15,7 @@
 package applier_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -24,6 +25,7 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cliapply "sigs.k8s.io/cli-utils/pkg/apply"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/poller"
 	"sigs.k8s.io/cli-utils/pkg/common"
@@ -136,7 +138,7 @@ func TestApplierRun(t *testing.T) {
 				a.Poller = tt.poller
 			}
 			// start writing to channel
-			go a.ApplyBundle(tt.bundle, opts)
+			go a.ApplyBundle(context.Background(), tt.bundle, opts)
 			var airEvents []events.Event
 			for e := range eventChan {
 				airEvents = append(airEvents, e)
@@ -168,3 +170,55 @@ func newBundle(path string, t *testing.T) document.Bundle {
 	require.NoError(t, err)
 	return b
 }
+
+func TestAdaptorInitializeServerSide(t *testing.T) {
+	tests := []struct {
+		name                 string
+		serverSide           applier.ServerSideOptions
+		expectedServerSide   bool
+		expectedForce        bool
+		expectedFieldManager string
+	}{
+		{
+			name:               "client-side apply",
+			serverSide:         applier.ServerSideOptions{},
+			expectedServerSide: false,
+			expectedForce:      false,
+		},
+		{
+			name: "server-side apply",
+			serverSide: applier.ServerSideOptions{
+				Enabled:        true,
+				ForceConflicts: true,
+				FieldManager:   "airship-ci",
+			},
+			expectedServerSide:   true,
+			expectedForce:        true,
+			expectedFieldManager: "airship-ci",
+		},
+		{
+			name:                 "server-side apply with default field manager",
+			serverSide:           applier.ServerSideOptions{Enabled: true},
+			expectedServerSide:   true,
+			expectedFieldManager: applier.DefaultFieldManager,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			f := k8stest.FakeFactory(t, []k8stest.ClientHandler{})
+			defer f.Cleanup()
+			cliApplier := cliapply.NewApplier(f, genericclioptions.IOStreams{Out: os.Stdout, ErrOut: os.Stderr})
+			adaptor := &applier.Adaptor{CliUtilsApplier: cliApplier}
+			// initialization fails later on, since there is no cluster to connect to, but apply
+			// options are set from the flags before that
+			_ = adaptor.Initialize(nil, tt.serverSide)
+			require.NotNil(t, cliApplier.ApplyOptions)
+			assert.Equal(t, tt.expectedServerSide, cliApplier.ApplyOptions.ServerSideApply)
+			assert.Equal(t, tt.expectedForce, cliApplier.ApplyOptions.ForceConflicts)
+			if tt.expectedServerSide {
+				assert.Equal(t, tt.expectedFieldManager, cliApplier.ApplyOptions.FieldManager)
+			}
+		})
+	}
+}
@@ -15,15 +15,106 @@
 package applier
 
 import (
-	"sigs.k8s.io/cli-utils/pkg/common"
-
+	"fmt"
 	"time"
+
+	clicommon "sigs.k8s.io/cli-utils/pkg/common"
+
+	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/document"
 )
 
 // ApplyOptions struct that hold options for apply operation
 type ApplyOptions struct {
 	WaitTimeout    time.Duration
-	DryRunStrategy common.DryRunStrategy
+	DryRunStrategy clicommon.DryRunStrategy
 	Prune          bool
-	BundleName     string
+	// BundleName is used as inventory ID
+	BundleName string
+	ServerSide ServerSideOptions
+	Inventory  InventoryOptions
+}
+
+// InventoryOptions defines inventory config map used to keep track of the applied objects
+type InventoryOptions struct {
+	// Namespace is DefaultNamespace if not set
+	Namespace string
+	// Name is airshipit-<inventory ID> if not set
+	Name   string
+	Labels map[string]string
+	// AdoptFrom lists previous inventory IDs, live inventory with one of these IDs is adopted
+	// if there is no inventory with the current ID. Only the namespace of the current inventory is searched
+	AdoptFrom []string
+}
+
+// NewApplyOptions returns apply options defined by the apply config, bundleName is used
+// as inventory ID unless the ID is set in the config
+func NewApplyOptions(cfg airshipv1.ApplyConfig, bundleName string) ApplyOptions {
+	if cfg.InventoryOptions.ID != "" {
+		bundleName = cfg.InventoryOptions.ID
+	}
+	return ApplyOptions{
+		DryRunStrategy: clicommon.DryRunNone,
+		Prune:          cfg.PruneOptions.Prune,
+		BundleName:     bundleName,
+		WaitTimeout:    time.Second * time.Duration(cfg.WaitOptions.Timeout),
+		ServerSide: ServerSideOptions{
+			Enabled:        cfg.ServerSideOptions.ServerSide,
+			FieldManager:   cfg.ServerSideOptions.FieldManager,
+			ForceConflicts: cfg.ServerSideOptions.ForceConflicts,
+		},
+		Inventory: InventoryOptions{
+			Namespace: cfg.InventoryOptions.Namespace,
+			Name:      cfg.InventoryOptions.Name,
+			Labels:    cfg.InventoryOptions.Labels,
+			AdoptFrom: cfg.InventoryOptions.AdoptFrom,
+		},
+	}
+}
+
+// ValidateApplyConfig makes sure that apply config has no conflicting or invalid settings
+func ValidateApplyConfig(cfg airshipv1.ApplyConfig) error {
+	if cfg.WaitOptions.Timeout < 0 {
+		return ErrInvalidWaitTimeout{Timeout: cfg.WaitOptions.Timeout}
+	}
+	ssOpts := cfg.ServerSideOptions
+	if !ssOpts.ServerSide && (ssOpts.FieldManager != "" || ssOpts.ForceConflicts) {
+		return ErrServerSideApplyDisabled{}
+	}
+	if _, exists := cfg.InventoryOptions.Labels[clicommon.InventoryLabel]; exists {
+		return ErrInvalidInventoryLabels{Label: clicommon.InventoryLabel}
+	}
+	return nil
+}
+
+// validateBundleInventory makes sure that inventory options are not set if the bundle defines its own
+// inventory, since they would be ignored
+func validateBundleInventory(bundle document.Bundle, opts airshipv1.ApplyInventoryOptions) error {
+	if opts.Namespace == "" && opts.Name == "" && opts.ID == "" && len(opts.Labels) == 0 {
+		return nil
+	}
+	invDocs, err := bundle.Select(inventorySelector())
+	if err != nil {
+		return err
+	}
+	if len(invDocs) > 0 {
+		return ErrInventoryOptionsIgnored{Inventory: fmt.Sprintf("%s/%s", invDocs[0].GetNamespace(), invDocs[0].GetName())}
+	}
+	return nil
+}
+
+// ServerSideOptions holds settings of server-side apply
+type ServerSideOptions struct {
+	// Enabled switches cli-utils applier from client-side to server-side apply
+	Enabled        bool
+	FieldManager   string
+	ForceConflicts bool
+}
+
+// fieldManager returns name of the field manager to use, DefaultFieldManager if it's not set
+func (o ServerSideOptions) fieldManager() string {
+	if o.FieldManager == "" {
+		return DefaultFieldManager
+	}
+	return o.FieldManager
 }
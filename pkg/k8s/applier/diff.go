@@ -0,0 +1,233 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package applier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pmezard/go-difflib/difflib"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/resource"
+	clicommon "sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+// DiffBundle compares documents of the bundle with the live objects using server-side dry-run apply
+// and prints a unified diff for every object that would be changed. If prune is enabled, objects listed
+// in the inventory but absent in the bundle are printed as well. Nothing is changed in the cluster
+func (a *Applier) DiffBundle(ctx context.Context, bundle document.Bundle, ao ApplyOptions) {
+	defer close(a.eventChannel)
+	if err := a.diffBundle(ctx, bundle, ao); err != nil {
+		handleError(a.eventChannel, err)
+	}
+}
+
+func (a *Applier) diffBundle(ctx context.Context, bundle document.Bundle, ao ApplyOptions) error {
+	if bundle == nil {
+		return ErrNilBundle{}
+	}
+	invDoc, err := bundle.SelectOne(inventorySelector())
+	if err != nil && errors.As(err, &document.ErrDocNotFound{}) {
+		// inventory object is not injected into the bundle, so it is only used to find live inventory
+		invDoc, err = NewInventoryDocument(ao.BundleName, ao.Inventory)
+	}
+	if err != nil {
+		return err
+	}
+
+	infos, err := a.ManifestReaderFactory(false, bundle, a.Factory).Read()
+	if err != nil {
+		return err
+	}
+
+	applied := make(map[object.ObjMetadata]bool)
+	for _, info := range infos {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		accessor, accErr := meta.Accessor(info.Object)
+		if accErr != nil {
+			return accErr
+		}
+		if _, isInventory := accessor.GetLabels()[clicommon.InventoryLabel]; isInventory {
+			continue
+		}
+		applied[infoToObjMetadata(info)] = true
+		if err = a.diffObject(info, ao.ServerSide.fieldManager()); err != nil {
+			return err
+		}
+	}
+
+	if !ao.Prune {
+		return nil
+	}
+	pruned, err := a.pruneCandidates(invDoc, ao.Inventory.AdoptFrom, applied)
+	if err != nil {
+		return err
+	}
+	for _, id := range pruned {
+		if _, err = fmt.Fprintf(a.Streams.Out, "%s would be pruned\n", objectName(id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffObject writes a diff between the live object and the result of its server-side dry-run apply
+func (a *Applier) diffObject(info *resource.Info, fieldManager string) error {
+	id := infoToObjMetadata(info)
+	log.Debugf("Comparing %s with the live object", objectName(id))
+	helper := resource.NewHelper(info.Client, info.Mapping)
+	live, err := helper.Get(info.Namespace, info.Name, false)
+	if apierror.IsNotFound(err) {
+		live = nil
+	} else if err != nil {
+		return err
+	}
+
+	data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, info.Object)
+	if err != nil {
+		return err
+	}
+	force := true
+	merged, err := helper.Patch(info.Namespace, info.Name, types.ApplyPatchType, data, &metav1.PatchOptions{
+		DryRun:       []string{metav1.DryRunAll},
+		Force:        &force,
+		FieldManager: fieldManager,
+	})
+	if err != nil {
+		return err
+	}
+
+	from, err := objectYAML(live)
+	if err != nil {
+		return err
+	}
+	to, err := objectYAML(merged)
+	if err != nil {
+		return err
+	}
+	diff, err := unifiedDiff(objectName(id), from, to)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(a.Streams.Out, diff)
+	return err
+}
+
+// pruneCandidates returns objects listed in the live inventory which are not applied anymore
+func (a *Applier) pruneCandidates(
+	invDoc document.Document,
+	adoptFrom []string,
+	applied map[object.ObjMetadata]bool) ([]object.ObjMetadata, error) {
+	clientSet, err := a.Factory.KubernetesClientSet()
+	if err != nil {
+		return nil, err
+	}
+	cmClient := clientSet.CoreV1().ConfigMaps(invDoc.GetNamespace())
+	cms, err := liveInventories(cmClient, invDoc.GetLabels()[clicommon.InventoryLabel])
+	if err != nil {
+		return nil, err
+	}
+	if len(cms) == 0 {
+		// previous inventory is going to be adopted by the apply
+		if _, cms, err = previousInventories(cmClient, adoptFrom); err != nil {
+			return nil, err
+		}
+	}
+
+	pruned := []object.ObjMetadata{}
+	seen := make(map[object.ObjMetadata]bool)
+	for _, cm := range cms {
+		for key := range cm.Data {
+			id, parseErr := object.ParseObjMetadata(key)
+			if parseErr != nil {
+				return nil, parseErr
+			}
+			if !applied[id] && !seen[id] {
+				seen[id] = true
+				pruned = append(pruned, id)
+			}
+		}
+	}
+	sort.Slice(pruned, func(i, j int) bool {
+		return objectName(pruned[i]) < objectName(pruned[j])
+	})
+	return pruned, nil
+}
+
+func infoToObjMetadata(info *resource.Info) object.ObjMetadata {
+	return object.ObjMetadata{
+		Namespace: info.Namespace,
+		Name:      info.Name,
+		GroupKind: info.Mapping.GroupVersionKind.GroupKind(),
+	}
+}
+
+// objectName returns human readable name of the object, e.g. apps/Deployment/default/nginx
+func objectName(id object.ObjMetadata) string {
+	name := id.GroupKind.Kind
+	if id.GroupKind.Group != "" {
+		name = fmt.Sprintf("%s/%s", id.GroupKind.Group, name)
+	}
+	if id.Namespace != "" {
+		name = fmt.Sprintf("%s/%s", name, id.Namespace)
+	}
+	return fmt.Sprintf("%s/%s", name, id.Name)
+}
+
+// objectYAML returns YAML representation of the object without managed fields,
+// which are changed by every apply and make the diff unreadable
+func objectYAML(obj runtime.Object) ([]byte, error) {
+	if obj == nil {
+		return nil, nil
+	}
+	var content map[string]interface{}
+	if u, ok := obj.(runtime.Unstructured); ok {
+		content = runtime.DeepCopyJSON(u.UnstructuredContent())
+	} else {
+		var err error
+		if content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err != nil {
+			return nil, err
+		}
+	}
+	unstructured.RemoveNestedField(content, "metadata", "managedFields")
+	return yaml.Marshal(content)
+}
+
+// unifiedDiff returns unified diff of two YAML representations of the object,
+// empty string is returned if they are equal
+func unifiedDiff(name string, from, to []byte) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(from)),
+		B:        difflib.SplitLines(string(to)),
+		FromFile: "live/" + name,
+		ToFile:   "merged/" + name,
+		Context:  3,
+	})
+}
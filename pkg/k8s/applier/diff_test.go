@@ -0,0 +1,162 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package applier_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/k8s/applier"
+	"opendev.org/airship/airshipctl/testutil"
+	k8stest "opendev.org/airship/airshipctl/testutil/k8sutils"
+)
+
+const (
+	liveRC = `{"apiVersion":"v1","kind":"ReplicationController",` +
+		`"metadata":{"name":"test-rc","namespace":"test"},"spec":{"replicas":1}}`
+	mergedRC = `{"apiVersion":"v1","kind":"ReplicationController",` +
+		`"metadata":{"name":"test-rc","namespace":"test","managedFields":[{"manager":"airshipctl"}]},` +
+		`"spec":{"replicas":2}}`
+	inventoryList = `{"apiVersion":"v1","kind":"ConfigMapList","items":[{"metadata":{` +
+		`"name":"airshipit-test-bundle-4bf1e4a","namespace":"airshipit",` +
+		`"labels":{"cli-utils.sigs.k8s.io/inventory-id":"test-bundle"}},` +
+		`"data":{"test_test-rc__ReplicationController":"","test_old-rc__ReplicationController":""}}]}`
+)
+
+// diffHandler serves live and dry-run applied replication controller along with the inventory
+type diffHandler struct {
+	live   string
+	merged string
+}
+
+func (h diffHandler) Handle(t *testing.T, req *http.Request) (*http.Response, bool, error) {
+	var body string
+	switch {
+	case req.URL.Path == "/namespaces/test/replicationcontrollers/test-rc" && req.Method == http.MethodGet:
+		if h.live == "" {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Header:     cmdtesting.DefaultHeader(),
+				Body:       cmdtesting.StringBody("")}, true, nil
+		}
+		body = h.live
+	case req.URL.Path == "/namespaces/test/replicationcontrollers/test-rc" && req.Method == http.MethodPatch:
+		assert.Equal(t, "All", req.URL.Query().Get("dryRun"))
+		body = h.merged
+	case req.URL.Path == "/api/v1/namespaces/airshipit/configmaps" && req.Method == http.MethodGet:
+		body = inventoryList
+	default:
+		return nil, false, nil
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     cmdtesting.DefaultHeader(),
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(body)))}, true, nil
+}
+
+func TestApplierDiff(t *testing.T) {
+	bundle := testutil.NewTestBundle(t, "testdata/source_bundle")
+	tests := []struct {
+		name           string
+		handler        diffHandler
+		prune          bool
+		nilBundle      bool
+		expectedErr    string
+		expectedOut    []string
+		notExpectedOut []string
+	}{
+		{
+			name:    "object is changed",
+			handler: diffHandler{live: liveRC, merged: mergedRC},
+			expectedOut: []string{
+				"--- live/ReplicationController/test/test-rc",
+				"+++ merged/ReplicationController/test/test-rc",
+				"-  replicas: 1",
+				"+  replicas: 2",
+			},
+			notExpectedOut: []string{"managedFields", "would be pruned"},
+		},
+		{
+			name:           "object is not changed",
+			handler:        diffHandler{live: liveRC, merged: liveRC},
+			notExpectedOut: []string{"live/", "merged/"},
+		},
+		{
+			name:        "object is created",
+			handler:     diffHandler{merged: liveRC},
+			expectedOut: []string{"+kind: ReplicationController"},
+		},
+		{
+			name:        "objects are pruned",
+			handler:     diffHandler{live: liveRC, merged: liveRC},
+			prune:       true,
+			expectedOut: []string{"ReplicationController/test/old-rc would be pruned"},
+			notExpectedOut: []string{
+				"ReplicationController/test/test-rc would be pruned",
+			},
+		},
+		{
+			name:        "nil bundle",
+			nilBundle:   true,
+			expectedErr: "nil bundle provided",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			f := k8stest.FakeFactory(t, []k8stest.ClientHandler{tt.handler})
+			defer f.Cleanup()
+			out := &bytes.Buffer{}
+			eventChan := make(chan events.Event)
+			a := applier.NewApplier(eventChan, f, genericclioptions.IOStreams{Out: out, ErrOut: out})
+			opts := applier.ApplyOptions{
+				BundleName: "test-bundle",
+				Prune:      tt.prune,
+			}
+			b := bundle
+			if tt.nilBundle {
+				b = nil
+			}
+			go a.DiffBundle(context.Background(), b, opts)
+			var errs []error
+			for e := range eventChan {
+				if e.Type == events.ErrorType {
+					errs = append(errs, e.ErrorEvent.Error)
+				}
+			}
+			if tt.expectedErr != "" {
+				require.Len(t, errs, 1)
+				assert.Contains(t, errs[0].Error(), tt.expectedErr)
+				return
+			}
+			require.Len(t, errs, 0)
+			for _, s := range tt.expectedOut {
+				assert.Contains(t, out.String(), s)
+			}
+			for _, s := range tt.notExpectedOut {
+				assert.NotContains(t, out.String(), s)
+			}
+		})
+	}
+}
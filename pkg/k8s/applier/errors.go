@@ -35,3 +35,58 @@ type ErrNilBundle struct {
 func (e ErrNilBundle) Error() string {
 	return "nil bundle provided"
 }
+
+// ErrInvalidWaitTimeout returned when wait timeout in KubernetesApply document is negative
+type ErrInvalidWaitTimeout struct {
+	Timeout int
+}
+
+func (e ErrInvalidWaitTimeout) Error() string {
+	return fmt.Sprintf("wait timeout must not be negative, got %d", e.Timeout)
+}
+
+// ErrServerSideApplyDisabled returned when server-side apply settings are set, but server-side apply is disabled
+type ErrServerSideApplyDisabled struct {
+}
+
+func (e ErrServerSideApplyDisabled) Error() string {
+	return "fieldManager and forceConflicts can only be set if serverSide is enabled"
+}
+
+// ErrInvalidApplyWave returned when value of the apply wave annotation is not an integer
+type ErrInvalidApplyWave struct {
+	Object string
+	Value  string
+}
+
+func (e ErrInvalidApplyWave) Error() string {
+	return fmt.Sprintf("apply wave of %s must be an integer, got %q", e.Object, e.Value)
+}
+
+// ErrWavesWithoutTimeout returned when the bundle is split into apply waves, but wait timeout is not set,
+// so the next wave could be applied before resources of the previous one are Current
+type ErrWavesWithoutTimeout struct {
+	Waves int
+}
+
+func (e ErrWavesWithoutTimeout) Error() string {
+	return fmt.Sprintf("wait timeout must be set to apply the bundle in %d waves", e.Waves)
+}
+
+// ErrInventoryOptionsIgnored returned when inventory options are set, but the bundle defines its own inventory
+type ErrInventoryOptionsIgnored struct {
+	Inventory string
+}
+
+func (e ErrInventoryOptionsIgnored) Error() string {
+	return fmt.Sprintf("inventory options can't be set, bundle defines inventory config map %s", e.Inventory)
+}
+
+// ErrInvalidInventoryLabels returned when inventory labels of KubernetesApply document contain a reserved label
+type ErrInvalidInventoryLabels struct {
+	Label string
+}
+
+func (e ErrInvalidInventoryLabels) Error() string {
+	return fmt.Sprintf("inventory label %s is reserved, set inventory id instead", e.Label)
+}
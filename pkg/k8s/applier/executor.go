@@ -15,15 +15,15 @@
 package applier
 
 import (
+	"context"
+	"fmt"
 	"io"
-	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/cli-utils/pkg/common"
 
 	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/document"
-	"opendev.org/airship/airshipctl/pkg/errors"
 	"opendev.org/airship/airshipctl/pkg/events"
 	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
 	"opendev.org/airship/airshipctl/pkg/k8s/utils"
@@ -35,6 +35,8 @@ import (
 type ExecutorOptions struct {
 	BundleName  string
 	ClusterName string
+	// KubeconfigContext is a context of the cluster in kubeconfig, cluster name is used if not set
+	KubeconfigContext string
 
 	ExecutorDocument document.Document
 	BundleFactory    document.BundleFactoryFunc
@@ -42,7 +44,7 @@ type ExecutorOptions struct {
 	Helper           ifc.Helper
 }
 
-var _ ifc.Executor = &Executor{}
+var _ ifc.DiffExecutor = &Executor{}
 
 // RegisterExecutor adds executor to phase executor registry
 func RegisterExecutor(registry map[schema.GroupVersionKind]ifc.ExecutorFactory) error {
@@ -58,12 +60,13 @@ func RegisterExecutor(registry map[schema.GroupVersionKind]ifc.ExecutorFactory)
 // registerExecutor is here so that executor in theory can be used outside phases
 func registerExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
 	return NewExecutor(ExecutorOptions{
-		ClusterName:      cfg.ClusterName,
-		BundleName:       cfg.PhaseName,
-		Helper:           cfg.Helper,
-		ExecutorDocument: cfg.ExecutorDocument,
-		BundleFactory:    cfg.BundleFactory,
-		Kubeconfig:       cfg.KubeConfig,
+		ClusterName:       cfg.ClusterName,
+		KubeconfigContext: cfg.KubeconfigContext,
+		BundleName:        cfg.PhaseName,
+		Helper:            cfg.Helper,
+		ExecutorDocument:  cfg.ExecutorDocument,
+		BundleFactory:     cfg.BundleFactory,
+		Kubeconfig:        cfg.KubeConfig,
 	})
 }
 
@@ -95,7 +98,7 @@ func NewExecutor(opts ExecutorOptions) (*Executor, error) {
 }
 
 // Run executor, should be performed in separate go routine
-func (e *Executor) Run(ch chan events.Event, runOpts ifc.RunOptions) {
+func (e *Executor) Run(ctx context.Context, ch chan events.Event, runOpts ifc.RunOptions) {
 	applier, filteredBundle, err := e.prepareApplier(ch)
 	if err != nil {
 		handleError(ch, err)
@@ -103,17 +106,23 @@ func (e *Executor) Run(ch chan events.Event, runOpts ifc.RunOptions) {
 		return
 	}
 	defer e.cleanup()
-	dryRunStrategy := common.DryRunNone
+	applyOptions := NewApplyOptions(e.apiObject.Config, e.Options.BundleName)
 	if runOpts.DryRun {
-		dryRunStrategy = common.DryRunClient
+		applyOptions.DryRunStrategy = common.DryRunClient
 	}
-	applyOptions := ApplyOptions{
-		DryRunStrategy: dryRunStrategy,
-		Prune:          e.apiObject.Config.PruneOptions.Prune,
-		BundleName:     e.Options.BundleName,
-		WaitTimeout:    time.Second * time.Duration(e.apiObject.Config.WaitOptions.Timeout),
+	applier.ApplyBundle(ctx, filteredBundle, applyOptions)
+}
+
+// Diff prints changes the executor would make to the cluster without applying them
+func (e *Executor) Diff(ctx context.Context, ch chan events.Event) {
+	applier, filteredBundle, err := e.prepareApplier(ch)
+	if err != nil {
+		handleError(ch, err)
+		close(ch)
+		return
 	}
-	applier.ApplyBundle(filteredBundle, applyOptions)
+	defer e.cleanup()
+	applier.DiffBundle(ctx, filteredBundle, NewApplyOptions(e.apiObject.Config, e.Options.BundleName))
 }
 
 func (e *Executor) prepareApplier(ch chan events.Event) (*Applier, document.Bundle, error) {
@@ -130,15 +139,74 @@ func (e *Executor) prepareApplier(ch chan events.Event) (*Applier, document.Bund
 	}
 	// set up cleanup only if all calls up to here were successful
 	e.cleanup = cleanup
-	// Use cluster name as context in kubeconfig file
-	factory := utils.FactoryFromKubeConfig(path, e.Options.ClusterName)
+	context := e.Options.KubeconfigContext
+	if context == "" {
+		context = e.Options.ClusterName
+	}
+	factory := utils.FactoryFromKubeConfig(path, context)
 	streams := utils.Streams()
 	return NewApplier(ch, factory, streams), bundle, nil
 }
 
 // Validate document set
 func (e *Executor) Validate() error {
-	return errors.ErrNotImplemented{}
+	if e.ExecutorBundle == nil {
+		return ErrNilBundle{}
+	}
+	if err := ValidateApplyConfig(e.apiObject.Config); err != nil {
+		return err
+	}
+	bundle, err := e.ExecutorBundle.SelectBundle(document.NewDeployToK8sSelector())
+	if err != nil {
+		return err
+	}
+	if err = validateBundleInventory(bundle, e.apiObject.Config.InventoryOptions); err != nil {
+		return err
+	}
+	docs, err := bundle.GetAllDocuments()
+	if err != nil {
+		return err
+	}
+	waves, err := bundleWaves(docs)
+	if err != nil {
+		return err
+	}
+	if len(waves) > 1 && e.apiObject.Config.WaitOptions.Timeout == 0 {
+		return ErrWavesWithoutTimeout{Waves: len(waves)}
+	}
+	return nil
+}
+
+// Details returns summary of the documents that are going to be applied
+func (e *Executor) Details() (string, error) {
+	if e.ExecutorBundle == nil {
+		return "", ErrNilBundle{}
+	}
+	bundle, err := e.ExecutorBundle.SelectBundle(document.NewDeployToK8sSelector())
+	if err != nil {
+		return "", err
+	}
+	docs, err := bundle.GetAllDocuments()
+	if err != nil {
+		return "", err
+	}
+	prune := "off"
+	if e.apiObject.Config.PruneOptions.Prune {
+		prune = "on"
+	}
+	details := fmt.Sprintf("applies %d documents to %s with prune %s and %ds wait",
+		len(docs), e.Options.ClusterName, prune, e.apiObject.Config.WaitOptions.Timeout)
+	if ssOpts := NewApplyOptions(e.apiObject.Config, e.Options.BundleName).ServerSide; ssOpts.Enabled {
+		details = fmt.Sprintf("%s using server-side apply as %s", details, ssOpts.fieldManager())
+	}
+	waves, err := bundleWaves(docs)
+	if err != nil {
+		return "", err
+	}
+	if len(waves) > 1 {
+		details = fmt.Sprintf("%s in %d apply waves %v", details, len(waves), waves)
+	}
+	return details, nil
 }
 
 // Render document set
@@ -16,6 +16,7 @@ package applier_test
 
 import (
 	"bytes"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -185,7 +186,7 @@ func TestExecutorRun(t *testing.T) {
 				require.NoError(t, err)
 				require.NotNil(t, exec)
 				ch := make(chan events.Event)
-				go exec.Run(ch, ifc.RunOptions{})
+				go exec.Run(context.Background(), ch, ifc.RunOptions{})
 				processor := events.NewDefaultProcessor(utils.Streams())
 				err = processor.Process(ch)
 				if tt.containsErr != "" {
@@ -199,6 +200,23 @@ func TestExecutorRun(t *testing.T) {
 	}
 }
 
+func TestExecutorDiff(t *testing.T) {
+	exec, err := applier.NewExecutor(
+		applier.ExecutorOptions{
+			ExecutorDocument: toKubernetesApply(t, ValidExecutorDocNamespaced),
+			Helper:           makeDefaultHelper(t),
+			BundleFactory:    testBundleFactory("testdata/source_bundle"),
+			Kubeconfig:       testKubeconfig(`invalid kubeconfig`),
+		})
+	require.NoError(t, err)
+	ch := make(chan events.Event)
+	go exec.Diff(context.Background(), ch)
+	processor := events.NewDefaultProcessor(utils.Streams())
+	err = processor.Process(ch)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no such file or directory")
+}
+
 func TestRender(t *testing.T) {
 	execDoc, err := document.NewDocumentFromBytes([]byte(ValidExecutorDoc))
 	require.NoError(t, err)
@@ -218,6 +236,179 @@ func TestRender(t *testing.T) {
 	assert.Contains(t, result, "ReplicationController")
 }
 
+func TestExecutorValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		execDoc     string
+		bundlePath  string
+		expectedErr error
+	}{
+		{
+			name:    "Success",
+			execDoc: ValidExecutorDoc,
+		},
+		{
+			name:       "Success apply waves",
+			execDoc:    ValidExecutorDoc,
+			bundlePath: "testdata/waves_bundle",
+		},
+		{
+			name:        "Error invalid apply wave",
+			execDoc:     ValidExecutorDoc,
+			bundlePath:  "testdata/invalid_wave_bundle",
+			expectedErr: applier.ErrInvalidApplyWave{Object: "ConfigMap/test/test-map", Value: "first"},
+		},
+		{
+			name: "Error apply waves without wait timeout",
+			execDoc: `apiVersion: airshipit.org/v1alpha1
+kind: KubernetesApply
+metadata:
+  name: kubernetes-apply
+config:
+  waitOptions:
+    timeout: 0
+`,
+			bundlePath:  "testdata/waves_bundle",
+			expectedErr: applier.ErrWavesWithoutTimeout{Waves: 3},
+		},
+		{
+			name: "Error negative timeout",
+			execDoc: `apiVersion: airshipit.org/v1alpha1
+kind: KubernetesApply
+metadata:
+  name: kubernetes-apply
+config:
+  waitOptions:
+    timeout: -1
+`,
+			expectedErr: applier.ErrInvalidWaitTimeout{Timeout: -1},
+		},
+		{
+			name: "Success server-side apply",
+			execDoc: `apiVersion: airshipit.org/v1alpha1
+kind: KubernetesApply
+metadata:
+  name: kubernetes-apply
+config:
+  serverSideOptions:
+    serverSide: true
+    fieldManager: airship-ci
+    forceConflicts: true
+`,
+		},
+		{
+			name: "Error field manager without server-side apply",
+			execDoc: `apiVersion: airshipit.org/v1alpha1
+kind: KubernetesApply
+metadata:
+  name: kubernetes-apply
+config:
+  serverSideOptions:
+    fieldManager: airship-ci
+`,
+			expectedErr: applier.ErrServerSideApplyDisabled{},
+		},
+		{
+			name: "Success inventory options",
+			execDoc: `apiVersion: airshipit.org/v1alpha1
+kind: KubernetesApply
+metadata:
+  name: kubernetes-apply
+config:
+  inventoryOptions:
+    namespace: inventories
+    name: initinfra
+    id: initinfra
+    labels:
+      app: airshipctl
+    adoptFrom:
+    - initinfra-ephemeral
+`,
+		},
+		{
+			name: "Error inventory options with bundle inventory",
+			execDoc: `apiVersion: airshipit.org/v1alpha1
+kind: KubernetesApply
+metadata:
+  name: kubernetes-apply
+config:
+  inventoryOptions:
+    namespace: inventories
+`,
+			bundlePath:  "testdata/inventory_bundle",
+			expectedErr: applier.ErrInventoryOptionsIgnored{Inventory: "inventories/inventory-map"},
+		},
+		{
+			name: "Success adopt with bundle inventory",
+			execDoc: `apiVersion: airshipit.org/v1alpha1
+kind: KubernetesApply
+metadata:
+  name: kubernetes-apply
+config:
+  inventoryOptions:
+    adoptFrom:
+    - initinfra-ephemeral
+`,
+			bundlePath: "testdata/inventory_bundle",
+		},
+		{
+			name: "Error reserved inventory label",
+			execDoc: `apiVersion: airshipit.org/v1alpha1
+kind: KubernetesApply
+metadata:
+  name: kubernetes-apply
+config:
+  inventoryOptions:
+    labels:
+      cli-utils.sigs.k8s.io/inventory-id: initinfra
+`,
+			expectedErr: applier.ErrInvalidInventoryLabels{Label: "cli-utils.sigs.k8s.io/inventory-id"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			execDoc, err := document.NewDocumentFromBytes([]byte(tt.execDoc))
+			require.NoError(t, err)
+			bundlePath := tt.bundlePath
+			if bundlePath == "" {
+				bundlePath = "testdata/source_bundle"
+			}
+			exec, err := applier.NewExecutor(applier.ExecutorOptions{
+				BundleFactory:    testBundleFactory(bundlePath),
+				ExecutorDocument: execDoc,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedErr, exec.Validate())
+		})
+	}
+}
+
+func TestExecutorDetails(t *testing.T) {
+	execDoc, err := document.NewDocumentFromBytes([]byte(ValidExecutorDoc))
+	require.NoError(t, err)
+	exec, err := applier.NewExecutor(applier.ExecutorOptions{
+		BundleFactory:    testBundleFactory("testdata/source_bundle"),
+		ExecutorDocument: execDoc,
+		ClusterName:      "target-cluster",
+	})
+	require.NoError(t, err)
+
+	details, err := exec.Details()
+	require.NoError(t, err)
+	assert.Regexp(t, "^applies [0-9]+ documents to target-cluster with prune off and 600s wait$", details)
+
+	exec, err = applier.NewExecutor(applier.ExecutorOptions{
+		BundleFactory:    testBundleFactory("testdata/waves_bundle"),
+		ExecutorDocument: execDoc,
+		ClusterName:      "target-cluster",
+	})
+	require.NoError(t, err)
+	details, err = exec.Details()
+	require.NoError(t, err)
+	assert.Regexp(t, `in 3 apply waves \[-1 0 2\]$`, details)
+}
+
 func makeDefaultHelper(t *testing.T) ifc.Helper {
 	t.Helper()
 	conf := &config.Config{
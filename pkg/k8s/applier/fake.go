@@ -46,7 +46,7 @@ func NewFakeAdaptor() FakeAdaptor {
 }
 
 // Initialize implements driver
-func (fa FakeAdaptor) Initialize(p poller.Poller) error {
+func (fa FakeAdaptor) Initialize(p poller.Poller, ssOpts ServerSideOptions) error {
 	return fa.initErr
 }
 
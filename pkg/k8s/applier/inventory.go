@@ -0,0 +1,99 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package applier
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	clicommon "sigs.k8s.io/cli-utils/pkg/common"
+
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+// adoptInventory makes live inventory created with one of the previous inventory IDs belong to the
+// current inventory ID, so that objects applied before the ID has changed are tracked by the current
+// inventory and are neither orphaned nor pruned. Nothing is done if inventory with the current ID exists.
+// Previous inventories are looked up in the namespace of the current one only
+func (a *Applier) adoptInventory(invDoc document.Document, ao ApplyOptions) error {
+	if len(ao.Inventory.AdoptFrom) == 0 {
+		return nil
+	}
+	clientSet, err := a.Factory.KubernetesClientSet()
+	if err != nil {
+		return err
+	}
+	cmClient := clientSet.CoreV1().ConfigMaps(invDoc.GetNamespace())
+	id := invDoc.GetLabels()[clicommon.InventoryLabel]
+	current, err := liveInventories(cmClient, id)
+	if err != nil {
+		return err
+	}
+	if len(current) > 0 {
+		log.Debugf("Inventory with ID %s exists, previous inventories are not adopted", id)
+		return nil
+	}
+
+	previousID, previous, err := previousInventories(cmClient, ao.Inventory.AdoptFrom)
+	if err != nil {
+		return err
+	}
+	if len(previous) == 0 {
+		log.Printf("No inventory with IDs %v is found in namespace %s, nothing is adopted by %s",
+			ao.Inventory.AdoptFrom, invDoc.GetNamespace(), id)
+		return nil
+	}
+	for i := range previous {
+		cm := &previous[i]
+		if ao.DryRunStrategy != clicommon.DryRunNone {
+			log.Printf("Inventory %s/%s with ID %s would be adopted by %s", cm.Namespace, cm.Name, previousID, id)
+			continue
+		}
+		cm.Labels[clicommon.InventoryLabel] = id
+		if _, err = cmClient.Update(cm); err != nil {
+			return err
+		}
+		log.Printf("Inventory %s/%s with ID %s is adopted by %s", cm.Namespace, cm.Name, previousID, id)
+	}
+	return nil
+}
+
+// previousInventories returns live inventories with the first of previous IDs that has any
+func previousInventories(cmClient corev1client.ConfigMapInterface, ids []string) (string, []v1.ConfigMap, error) {
+	for _, id := range ids {
+		cms, err := liveInventories(cmClient, id)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(cms) > 0 {
+			return id, cms, nil
+		}
+	}
+	return "", nil, nil
+}
+
+// liveInventories returns inventory config maps with the inventory ID
+func liveInventories(cmClient corev1client.ConfigMapInterface, id string) ([]v1.ConfigMap, error) {
+	cms, err := cmClient.List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", clicommon.InventoryLabel, id),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cms.Items, nil
+}
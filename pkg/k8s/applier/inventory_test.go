@@ -0,0 +1,180 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package applier_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"sigs.k8s.io/cli-utils/pkg/common"
+
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/k8s/applier"
+	"opendev.org/airship/airshipctl/testutil"
+	k8stest "opendev.org/airship/airshipctl/testutil/k8sutils"
+)
+
+const inventoryCM = `{"apiVersion":"v1","kind":"ConfigMapList","items":[{"metadata":{` +
+	`"name":"airshipit-%[1]s-4bf1e4a","namespace":"airshipit",` +
+	`"labels":{"cli-utils.sigs.k8s.io/inventory-id":"%[1]s"}},"data":{}}]}`
+
+// adoptionHandler serves live inventories by inventory ID and records updated inventories
+type adoptionHandler struct {
+	liveIDs []string
+	updated []string
+}
+
+func (h *adoptionHandler) Handle(t *testing.T, req *http.Request) (*http.Response, bool, error) {
+	var body string
+	switch {
+	case req.URL.Path == "/api/v1/namespaces/airshipit/configmaps" && req.Method == http.MethodGet:
+		body = `{"apiVersion":"v1","kind":"ConfigMapList","items":[]}`
+		for _, id := range h.liveIDs {
+			if req.URL.Query().Get("labelSelector") == common.InventoryLabel+"="+id {
+				body = fmt.Sprintf(inventoryCM, id)
+			}
+		}
+	case req.URL.Path == "/api/v1/namespaces/airshipit/configmaps/airshipit-test-bundle-4bf1e4a" &&
+		req.Method == http.MethodPut:
+		b, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		h.updated = append(h.updated, string(b))
+		body = string(b)
+	default:
+		return nil, false, nil
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     cmdtesting.DefaultHeader(),
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(body)))}, true, nil
+}
+
+func TestApplierAdoptInventory(t *testing.T) {
+	bundle := testutil.NewTestBundle(t, "testdata/source_bundle")
+	tests := []struct {
+		name            string
+		liveIDs         []string
+		adoptFrom       []string
+		dryRun          common.DryRunStrategy
+		expectedUpdates int
+	}{
+		{
+			name:            "previous inventory is adopted",
+			liveIDs:         []string{"test-bundle"},
+			adoptFrom:       []string{"missing-bundle", "test-bundle"},
+			expectedUpdates: 1,
+		},
+		{
+			name:      "current inventory exists",
+			liveIDs:   []string{"new-bundle", "test-bundle"},
+			adoptFrom: []string{"test-bundle"},
+		},
+		{
+			name:      "previous inventory is not found",
+			adoptFrom: []string{"test-bundle"},
+		},
+		{
+			name:      "dry run",
+			liveIDs:   []string{"test-bundle"},
+			adoptFrom: []string{"test-bundle"},
+			dryRun:    common.DryRunClient,
+		},
+		{
+			name:    "adoption is not requested",
+			liveIDs: []string{"test-bundle"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &adoptionHandler{liveIDs: tt.liveIDs}
+			f := k8stest.FakeFactory(t, []k8stest.ClientHandler{handler, &k8stest.NamespaceHandler{}})
+			defer f.Cleanup()
+			eventChan := make(chan events.Event)
+			out := &bytes.Buffer{}
+			a := applier.NewApplier(eventChan, f, genericclioptions.IOStreams{Out: out, ErrOut: out})
+			a.Driver = applier.NewFakeAdaptor().WithEvents(k8stest.SuccessEvents())
+			opts := applier.ApplyOptions{
+				BundleName:     "new-bundle",
+				DryRunStrategy: tt.dryRun,
+				Inventory:      applier.InventoryOptions{AdoptFrom: tt.adoptFrom},
+			}
+			go a.ApplyBundle(context.Background(), bundle, opts)
+			var errs []error
+			for e := range eventChan {
+				if e.Type == events.ErrorType {
+					errs = append(errs, e.ErrorEvent.Error)
+				}
+			}
+			require.Len(t, errs, 0)
+			require.Len(t, handler.updated, tt.expectedUpdates)
+			for _, u := range handler.updated {
+				assert.Contains(t, u, `"cli-utils.sigs.k8s.io/inventory-id":"new-bundle"`)
+			}
+		})
+	}
+}
+
+func TestNewInventoryDocument(t *testing.T) {
+	tests := []struct {
+		name              string
+		opts              applier.InventoryOptions
+		expectedNamespace string
+		expectedName      string
+		expectedLabels    map[string]string
+	}{
+		{
+			name:              "defaults",
+			expectedNamespace: applier.DefaultNamespace,
+			expectedName:      "airshipit-test-bundle",
+			expectedLabels:    map[string]string{common.InventoryLabel: "test-bundle"},
+		},
+		{
+			name: "custom namespace name and labels",
+			opts: applier.InventoryOptions{
+				Namespace: "inventories",
+				Name:      "custom",
+				Labels: map[string]string{
+					"app":                 "airshipctl",
+					common.InventoryLabel: "overridden",
+				},
+			},
+			expectedNamespace: "inventories",
+			expectedName:      "custom",
+			expectedLabels: map[string]string{
+				"app":                 "airshipctl",
+				common.InventoryLabel: "test-bundle",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := applier.NewInventoryDocument("test-bundle", tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedNamespace, doc.GetNamespace())
+			assert.Equal(t, tt.expectedName, doc.GetName())
+			assert.Equal(t, tt.expectedLabels, doc.GetLabels())
+		})
+	}
+}
@@ -0,0 +1,156 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package applier
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/cli-runtime/pkg/resource"
+	cliapply "sigs.k8s.io/cli-utils/pkg/apply"
+	applyevent "sigs.k8s.io/cli-utils/pkg/apply/event"
+	clicommon "sigs.k8s.io/cli-utils/pkg/common"
+
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+// ApplyWaveAnnotation groups resources of the bundle into waves, value of the annotation must be
+// an integer, resources without the annotation belong to wave 0. Waves are applied in ascending order
+const ApplyWaveAnnotation = "airshipit.org/apply-wave"
+
+// applyWave is a group of resources sharing the same value of the apply wave annotation
+type applyWave struct {
+	wave  int
+	infos []*resource.Info
+}
+
+// splitWaves separates inventory object from other resources and groups them into waves sorted in
+// the order they must be applied
+func splitWaves(infos []*resource.Info) ([]*resource.Info, []applyWave, error) {
+	var inventory []*resource.Info
+	byWave := map[int][]*resource.Info{}
+	for _, info := range infos {
+		accessor, err := meta.Accessor(info.Object)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, isInventory := accessor.GetLabels()[clicommon.InventoryLabel]; isInventory {
+			inventory = append(inventory, info)
+			continue
+		}
+		kind := info.Object.GetObjectKind().GroupVersionKind().Kind
+		wave, err := waveOf(fmt.Sprintf("%s/%s/%s", kind, info.Namespace, info.Name), accessor.GetAnnotations())
+		if err != nil {
+			return nil, nil, err
+		}
+		byWave[wave] = append(byWave[wave], info)
+	}
+	waves := make([]applyWave, 0, len(byWave))
+	for wave, waveInfos := range byWave {
+		waves = append(waves, applyWave{wave: wave, infos: waveInfos})
+	}
+	sort.Slice(waves, func(i, j int) bool { return waves[i].wave < waves[j].wave })
+	return inventory, waves, nil
+}
+
+// bundleWaves returns sorted apply waves of the documents, inventory documents are not a part of any wave
+func bundleWaves(docs []document.Document) ([]int, error) {
+	unique := map[int]struct{}{}
+	for _, doc := range docs {
+		if _, isInventory := doc.GetLabels()[clicommon.InventoryLabel]; isInventory {
+			continue
+		}
+		object := fmt.Sprintf("%s/%s/%s", doc.GetKind(), doc.GetNamespace(), doc.GetName())
+		wave, err := waveOf(object, doc.GetAnnotations())
+		if err != nil {
+			return nil, err
+		}
+		unique[wave] = struct{}{}
+	}
+	waves := make([]int, 0, len(unique))
+	for wave := range unique {
+		waves = append(waves, wave)
+	}
+	sort.Ints(waves)
+	return waves, nil
+}
+
+// waveOf returns apply wave of the object defined by its annotations
+func waveOf(object string, annotations map[string]string) (int, error) {
+	value, exists := annotations[ApplyWaveAnnotation]
+	if !exists {
+		return 0, nil
+	}
+	wave, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, ErrInvalidApplyWave{Object: object, Value: value}
+	}
+	return wave, nil
+}
+
+// applyWaves applies waves one by one, the next wave is started only after resources of the previous
+// one have become Current, so wait timeout must be set. Every wave is applied along with the resources
+// of the previous waves, so that the inventory always tracks all of them, and pruning is done only with
+// the last wave when the whole bundle is applied
+func (a *Applier) applyWaves(ctx context.Context, inventory []*resource.Info, waves []applyWave, ao ApplyOptions) {
+	infos := inventory
+	for i, wave := range waves {
+		infos = append(infos, wave.infos...)
+		opts := cliApplyOptions(ao)
+		opts.NoPrune = opts.NoPrune || i < len(waves)-1
+		a.eventChannel <- applyWaveEvent(events.ApplyWaveStart, wave.wave,
+			fmt.Sprintf("applying %d resources", len(wave.infos)))
+		if !a.run(ctx, infos, opts) {
+			log.Printf("Apply wave %d has failed, next waves are not applied", wave.wave)
+			return
+		}
+		message := fmt.Sprintf("%d resources are applied", len(wave.infos))
+		if opts.EmitStatusEvents && ao.DryRunStrategy == clicommon.DryRunNone {
+			message = fmt.Sprintf("%d resources are applied and Current", len(wave.infos))
+		}
+		a.eventChannel <- applyWaveEvent(events.ApplyWaveEnd, wave.wave, message)
+	}
+}
+
+// run applies resources and forwards applier events, false is returned if apply has failed or was cancelled
+func (a *Applier) run(ctx context.Context, infos []*resource.Info, opts cliapply.Options) bool {
+	succeeded := true
+	for e := range a.Driver.Run(ctx, infos, opts) {
+		if e.Type == applyevent.ErrorType {
+			succeeded = false
+		}
+		a.eventChannel <- events.Event{
+			Type:         events.ApplierType,
+			ApplierEvent: e,
+		}
+	}
+	return succeeded && ctx.Err() == nil
+}
+
+func applyWaveEvent(op events.ApplyWaveOperation, wave int, message string) events.Event {
+	return events.Event{
+		Type: events.ApplyWaveType,
+		ApplyWaveEvent: events.ApplyWaveEvent{
+			Operation: op,
+			Wave:      wave,
+			Message:   message,
+		},
+	}
+}
@@ -0,0 +1,164 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package applier_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	cliapply "sigs.k8s.io/cli-utils/pkg/apply"
+	applyevent "sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/poller"
+
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/k8s/applier"
+	k8stest "opendev.org/airship/airshipctl/testutil/k8sutils"
+)
+
+// wavesDriver records names of the resources and prune option of every apply run
+type wavesDriver struct {
+	// failedRun is a number of the run which reports an error, runs don't fail if it's 0
+	failedRun int
+	runs      [][]string
+	noPrune   []bool
+}
+
+func (d *wavesDriver) Initialize(_ poller.Poller, _ applier.ServerSideOptions) error {
+	return nil
+}
+
+func (d *wavesDriver) Run(_ context.Context, infos []*resource.Info,
+	options cliapply.Options) <-chan applyevent.Event {
+	names := []string{}
+	for _, info := range infos {
+		names = append(names, info.Name)
+	}
+	d.runs = append(d.runs, names)
+	d.noPrune = append(d.noPrune, options.NoPrune)
+	ch := make(chan applyevent.Event, 1)
+	defer close(ch)
+	if len(d.runs) == d.failedRun {
+		ch <- applyevent.Event{
+			Type:       applyevent.ErrorType,
+			ErrorEvent: applyevent.ErrorEvent{Err: fmt.Errorf("apply-error")},
+		}
+	}
+	return ch
+}
+
+func TestApplierWaves(t *testing.T) {
+	tests := []struct {
+		name            string
+		bundlePath      string
+		failedRun       int
+		noWait          bool
+		expectedRuns    [][]string
+		expectedNoPrune []bool
+		expectedWaves   []string
+		expectedErr     string
+	}{
+		{
+			name:       "waves are applied in order",
+			bundlePath: "testdata/waves_bundle",
+			expectedRuns: [][]string{
+				{"airshipit-test-bundle", "first-map"},
+				{"airshipit-test-bundle", "first-map", "test-rc"},
+				{"airshipit-test-bundle", "first-map", "test-rc", "last-map"},
+			},
+			expectedNoPrune: []bool{true, true, false},
+			expectedWaves:   []string{"start -1", "end -1", "start 0", "end 0", "start 2", "end 2"},
+		},
+		{
+			name:       "failed wave stops apply",
+			bundlePath: "testdata/waves_bundle",
+			failedRun:  2,
+			expectedRuns: [][]string{
+				{"airshipit-test-bundle", "first-map"},
+				{"airshipit-test-bundle", "first-map", "test-rc"},
+			},
+			expectedNoPrune: []bool{true, true},
+			expectedWaves:   []string{"start -1", "end -1", "start 0"},
+			expectedErr:     "apply-error",
+		},
+		{
+			name:            "bundle without waves",
+			bundlePath:      "testdata/source_bundle",
+			expectedRuns:    [][]string{{"test-rc", "airshipit-test-bundle"}},
+			expectedNoPrune: []bool{false},
+		},
+		{
+			name:        "waves without wait timeout",
+			bundlePath:  "testdata/waves_bundle",
+			noWait:      true,
+			expectedErr: "wait timeout must be set to apply the bundle in 3 waves",
+		},
+		{
+			name:        "invalid wave",
+			bundlePath:  "testdata/invalid_wave_bundle",
+			expectedErr: `apply wave of ConfigMap/test/test-map must be an integer, got "first"`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			f := k8stest.FakeFactory(t, []k8stest.ClientHandler{&k8stest.NamespaceHandler{}})
+			defer f.Cleanup()
+			out := &bytes.Buffer{}
+			eventChan := make(chan events.Event)
+			a := applier.NewApplier(eventChan, f, genericclioptions.IOStreams{Out: out, ErrOut: out})
+			driver := &wavesDriver{failedRun: tt.failedRun}
+			a.Driver = driver
+			opts := applier.ApplyOptions{
+				BundleName:  "test-bundle",
+				Prune:       true,
+				WaitTimeout: time.Minute,
+			}
+			if tt.noWait {
+				opts.WaitTimeout = 0
+			}
+			go a.ApplyBundle(context.Background(), newBundle(tt.bundlePath, t), opts)
+			var errs []error
+			var waves []string
+			for e := range eventChan {
+				if err := events.ErrorOf(e); err != nil {
+					errs = append(errs, err)
+				}
+				if e.Type == events.ApplyWaveType {
+					op := "start"
+					if e.ApplyWaveEvent.Operation == events.ApplyWaveEnd {
+						op = "end"
+					}
+					waves = append(waves, fmt.Sprintf("%s %d", op, e.ApplyWaveEvent.Wave))
+				}
+			}
+			if tt.expectedErr != "" {
+				require.Len(t, errs, 1)
+				assert.Contains(t, errs[0].Error(), tt.expectedErr)
+			} else {
+				assert.Len(t, errs, 0)
+			}
+			assert.Equal(t, tt.expectedRuns, driver.runs)
+			assert.Equal(t, tt.expectedNoPrune, driver.noPrune)
+			assert.Equal(t, tt.expectedWaves, waves)
+		})
+	}
+}
@@ -95,6 +95,38 @@ func NewClient(cfg *config.Config) (Interface, error) {
 	return client, nil
 }
 
+// NewClientFromKubeConfig creates a Client for the cluster defined by the context of
+// the kubeconfig file, current context is used if context name is empty
+func NewClientFromKubeConfig(kubeconfigPath, contextName string) (Interface, error) {
+	client := new(Client)
+	var err error
+
+	f := k8sutils.FactoryFromKubeConfig(kubeconfigPath, contextName)
+	client.kubectl = kubectl.NewKubectl(f)
+
+	client.clientSet, err = f.KubernetesClientSet()
+	if err != nil {
+		return nil, err
+	}
+
+	client.dynamicClient, err = f.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	restConfig, err := f.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client.apixClient, err = apix.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
 // ClientSet returns the ClientSet interface
 func (c *Client) ClientSet() kubernetes.Interface {
 	return c.clientSet
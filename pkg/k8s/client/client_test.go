@@ -51,3 +51,13 @@ func TestNewClient(t *testing.T) {
 	assert.NotNil(t, client.ApiextensionsClientSet())
 	assert.NotNil(t, client.Kubectl())
 }
+
+func TestNewClientFromKubeConfig(t *testing.T) {
+	client, err := client.NewClientFromKubeConfig(kubeconfigPath, "")
+	assert.NoError(t, err)
+	assert.NotNil(t, client)
+	assert.NotNil(t, client.ClientSet())
+	assert.NotNil(t, client.DynamicClient())
+	assert.NotNil(t, client.ApiextensionsClientSet())
+	assert.NotNil(t, client.Kubectl())
+}
@@ -15,21 +15,30 @@
 package kubeconfig
 
 import (
+	"fmt"
 	"path/filepath"
+	"reflect"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
 	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/document"
-	"opendev.org/airship/airshipctl/pkg/errors"
+	"opendev.org/airship/airshipctl/pkg/k8s/client"
+	"opendev.org/airship/airshipctl/pkg/log"
 	"opendev.org/airship/airshipctl/pkg/util"
 )
 
 // KubeconfigDefaultFileName is a default name for kubeconfig
 const KubeconfigDefaultFileName = "kubeconfig"
 
+// ClientFactory creates kubernetes client for the cluster defined by the context of the kubeconfig file
+type ClientFactory func(kubeconfigPath, contextName string) (client.Interface, error)
+
 // NewBuilder returns instance of kubeconfig builder.
 func NewBuilder() *Builder {
-	return &Builder{}
+	return &Builder{clientFactory: client.NewClientFromKubeConfig}
 }
 
 // Builder is an object that allows to build a kubeconfig based on various provided sources
@@ -40,7 +49,11 @@ type Builder struct {
 	clusterName string
 	root        string
 
-	clusterMap clustermap.ClusterMap
+	clusterMap    clustermap.ClusterMap
+	clientFactory ClientFactory
+	// children are clusters whose kubeconfig is being built from the current one,
+	// used to detect cycles in cluster map
+	children []string
 }
 
 // WithPath allows to set path to prexisting kubeconfig
@@ -67,6 +80,12 @@ func (b *Builder) WithClusterName(clusterName string) *Builder {
 	return b
 }
 
+// WithClientFactory allows to set a factory used to create a client to the parent cluster
+func (b *Builder) WithClientFactory(f ClientFactory) *Builder {
+	b.clientFactory = f
+	return b
+}
+
 // WithTempRoot allows to set temp root for kubeconfig
 func (b *Builder) WithTempRoot(root string) *Builder {
 	b.root = root
@@ -80,11 +99,7 @@ func (b *Builder) Build() Interface {
 		fs := document.NewDocumentFs()
 		return NewKubeConfig(FromFile(b.path, fs), InjectFilePath(b.path, fs), InjectTempRoot(b.root))
 	case b.fromParent():
-		// TODO add method that would get kubeconfig from parent cluster and glue it together
-		// with parent kubeconfig if needed
-		return NewKubeConfig(func() ([]byte, error) {
-			return nil, errors.ErrNotImplemented{}
-		})
+		return NewKubeConfig(b.fromParentSource(), InjectTempRoot(b.root))
 	case b.bundlePath != "":
 		return NewKubeConfig(FromBundle(b.bundlePath), InjectTempRoot(b.root))
 	default:
@@ -95,6 +110,20 @@ func (b *Builder) Build() Interface {
 	}
 }
 
+// Source returns a human readable description of the source kubeconfig is built from
+func (b *Builder) Source() string {
+	switch {
+	case b.path != "":
+		return fmt.Sprintf("file %s", b.path)
+	case b.fromParent():
+		return fmt.Sprintf("secret in the parent cluster of %s", b.clusterName)
+	case b.bundlePath != "":
+		return fmt.Sprintf("KubeConfig document in bundle %s", b.bundlePath)
+	default:
+		return fmt.Sprintf("file %s", filepath.Join(util.UserHomeDir(), config.AirshipConfigDir, KubeconfigDefaultFileName))
+	}
+}
+
 // fromParent checks if we should get kubeconfig from parent cluster secret
 func (b *Builder) fromParent() bool {
 	if b.clusterMap == nil {
@@ -102,3 +131,112 @@ func (b *Builder) fromParent() bool {
 	}
 	return b.clusterMap.DynamicKubeConfig(b.clusterName)
 }
+
+// fromParentSource returns KubeSource type, that extracts kubeconfig of the cluster from the secret
+// in its parent cluster and merges it with the parent kubeconfig, so both clusters can be reached
+// using kubeconfig contexts defined in cluster map. Parent kubeconfig is built the same way, so it may be
+// extracted from the secret in the grandparent cluster as well
+func (b *Builder) fromParentSource() KubeSourceFunc {
+	return func() ([]byte, error) {
+		parentName, err := b.clusterMap.ParentCluster(b.clusterName)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range b.children {
+			if child == parentName {
+				return nil, ErrClusterMapCycle{ClusterName: parentName}
+			}
+		}
+
+		parentBuilder := *b
+		parentBuilder.clusterName = parentName
+		parentBuilder.children = append(append([]string{}, b.children...), b.clusterName)
+		parentPath, cleanup, err := parentBuilder.Build().GetFile()
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+
+		opts, err := b.secretOptions()
+		if err != nil {
+			return nil, err
+		}
+		parentContext, err := b.clusterMap.ClusterKubeconfigContext(parentName)
+		if err != nil {
+			return nil, err
+		}
+		context, err := b.clusterMap.ClusterKubeconfigContext(b.clusterName)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Debugf("Getting kubeconfig of cluster %s from parent cluster %s", b.clusterName, parentName)
+		if opts.Client, err = b.clientFactory(parentPath, parentContext); err != nil {
+			return nil, err
+		}
+		data, err := GetKubeconfigFromSecret(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		parentConfig, err := clientcmd.LoadFromFile(parentPath)
+		if err != nil {
+			return nil, err
+		}
+		childConfig, err := clientcmd.Load(data)
+		if err != nil {
+			return nil, err
+		}
+		if err = mergeContext(parentConfig, childConfig, b.clusterName, context); err != nil {
+			return nil, err
+		}
+		return clientcmd.Write(*parentConfig)
+	}
+}
+
+// secretOptions returns options to extract kubeconfig from the secret defined for the cluster in cluster map
+func (b *Builder) secretOptions() (*FromClusterOptions, error) {
+	namespace, err := b.clusterMap.ClusterNamespace(b.clusterName)
+	if err != nil {
+		return nil, err
+	}
+	secretName, err := b.clusterMap.ClusterKubeconfigSecretName(b.clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return &FromClusterOptions{
+		ClusterName: b.clusterName,
+		Namespace:   namespace,
+		SecretName:  secretName,
+	}, nil
+}
+
+// mergeContext adds current context of the src kubeconfig of the cluster along with its cluster and
+// user to the dst kubeconfig under the given name and makes it current. Cluster and user of the src
+// kubeconfig must not replace different ones with the same names defined in the dst kubeconfig
+func mergeContext(dst, src *clientcmdapi.Config, clusterName, contextName string) error {
+	context, exists := src.Contexts[src.CurrentContext]
+	if !exists {
+		return ErrNoCurrentContext{ClusterName: clusterName}
+	}
+	cluster, exists := src.Clusters[context.Cluster]
+	if !exists {
+		return ErrNoCurrentContext{ClusterName: clusterName}
+	}
+	authInfo, exists := src.AuthInfos[context.AuthInfo]
+	if !exists {
+		return ErrNoCurrentContext{ClusterName: clusterName}
+	}
+	if existing, exists := dst.Clusters[context.Cluster]; exists && !reflect.DeepEqual(existing, cluster) {
+		return ErrKubeconfigNameClash{ClusterName: clusterName, Entry: "cluster", Name: context.Cluster}
+	}
+	if existing, exists := dst.AuthInfos[context.AuthInfo]; exists && !reflect.DeepEqual(existing, authInfo) {
+		return ErrKubeconfigNameClash{ClusterName: clusterName, Entry: "user", Name: context.AuthInfo}
+	}
+
+	dst.Clusters[context.Cluster] = cluster
+	dst.AuthInfos[context.AuthInfo] = authInfo
+	dst.Contexts[contextName] = context
+	dst.CurrentContext = contextName
+	return nil
+}
@@ -16,19 +16,70 @@ package kubeconfig_test
 
 import (
 	"bytes"
+	"fmt"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
 	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/k8s/client"
+	"opendev.org/airship/airshipctl/pkg/k8s/client/fake"
 	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
 	"opendev.org/airship/airshipctl/pkg/util"
 )
 
+// capiKubeconfig is a kubeconfig stored by CAPI in <cluster>-kubeconfig secret
+const capiKubeconfig = `apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority-data: ca-data
+    server: https://10.0.1.7:6443
+  name: %[1]s
+contexts:
+- context:
+    cluster: %[1]s
+    user: %[1]s-admin
+  name: %[1]s-admin@%[1]s
+current-context: %[1]s-admin@%[1]s
+kind: Config
+preferences: {}
+users:
+- name: %[1]s-admin
+  user:
+    client-certificate-data: cert-data
+    client-key-data: client-keydata
+`
+
+// fakeClientFactory returns a factory of clients to the cluster with kubeconfig secrets of given clusters
+func fakeClientFactory(t *testing.T, clusters ...string) kubeconfig.ClientFactory {
+	objs := []runtime.Object{}
+	for _, cluster := range clusters {
+		objs = append(objs, &coreV1.Secret{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      cluster + "-kubeconfig",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{
+				"value": []byte(fmt.Sprintf(capiKubeconfig, cluster)),
+			},
+		})
+	}
+	return func(kubeconfigPath, _ string) (client.Interface, error) {
+		// make sure that the parent kubeconfig is written to the file before client is created
+		_, err := clientcmd.LoadFromFile(kubeconfigPath)
+		require.NoError(t, err)
+		return fake.NewClient(fake.WithTypedObjects(objs...)), nil
+	}
+}
+
 func TestBuilder(t *testing.T) {
 	t.Run("Only bundle", func(t *testing.T) {
 		builder := kubeconfig.NewBuilder().WithBundle("testdata")
@@ -67,18 +118,122 @@ func TestBuilder(t *testing.T) {
 			},
 		}
 		builder := kubeconfig.NewBuilder().
+			WithBundle("testdata").
 			WithClusterMap(clustermap.NewClusterMap(clusterMap)).
-			WithClusterName(childCluster)
+			WithClusterName(childCluster).
+			WithClientFactory(fakeClientFactory(t, childCluster))
 		kube := builder.Build()
-		// This should not be implemented yet, and we need to check that we are getting there
 		require.NotNil(t, kube)
-		filePath, cleanup, err := kube.GetFile()
+		buf := bytes.NewBuffer([]byte{})
+		err := kube.Write(buf)
+		require.NoError(t, err)
+
+		merged, err := clientcmd.Load(buf.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, childCluster, merged.CurrentContext)
+		assert.Contains(t, merged.Contexts, childCluster)
+		// parent context from the bundle is preserved
+		assert.Contains(t, merged.Contexts, "dummy_cluster")
+		assert.Contains(t, merged.Clusters, childCluster)
+	})
+
+	t.Run("Multi-level cluster map", func(t *testing.T) {
+		clusterMap := &v1alpha1.ClusterMap{
+			Map: map[string]*v1alpha1.Cluster{
+				"child": {
+					Parent:            "parent",
+					DynamicKubeConfig: true,
+				},
+				"parent": {
+					Parent:            "grandparent",
+					DynamicKubeConfig: true,
+				},
+				"grandparent": {},
+			},
+		}
+		builder := kubeconfig.NewBuilder().
+			WithBundle("testdata").
+			WithClusterMap(clustermap.NewClusterMap(clusterMap)).
+			WithClusterName("child").
+			WithClientFactory(fakeClientFactory(t, "child", "parent"))
+		buf := bytes.NewBuffer([]byte{})
+		err := builder.Build().Write(buf)
+		require.NoError(t, err)
+
+		merged, err := clientcmd.Load(buf.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, "child", merged.CurrentContext)
+		for _, context := range []string{"child", "parent", "dummy_cluster"} {
+			assert.Contains(t, merged.Contexts, context)
+		}
+	})
+
+	t.Run("Secret not found in parent", func(t *testing.T) {
+		clusterMap := &v1alpha1.ClusterMap{
+			Map: map[string]*v1alpha1.Cluster{
+				"child": {
+					Parent:            "parent",
+					DynamicKubeConfig: true,
+				},
+			},
+		}
+		builder := kubeconfig.NewBuilder().
+			WithBundle("testdata").
+			WithClusterMap(clustermap.NewClusterMap(clusterMap)).
+			WithClusterName("child").
+			WithClientFactory(fakeClientFactory(t))
+		filePath, cleanup, err := builder.Build().GetFile()
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "not implemented")
 		assert.Equal(t, "", filePath)
 		require.Nil(t, cleanup)
 	})
 
+	t.Run("Cluster name clash with parent kubeconfig", func(t *testing.T) {
+		// the bundle kubeconfig defines a different cluster with the same name
+		childCluster := "dummycluster_ephemeral"
+		clusterMap := &v1alpha1.ClusterMap{
+			Map: map[string]*v1alpha1.Cluster{
+				childCluster: {
+					Parent:            "parent",
+					DynamicKubeConfig: true,
+				},
+				"parent": {},
+			},
+		}
+		builder := kubeconfig.NewBuilder().
+			WithBundle("testdata").
+			WithClusterMap(clustermap.NewClusterMap(clusterMap)).
+			WithClusterName(childCluster).
+			WithClientFactory(fakeClientFactory(t, childCluster))
+		_, _, err := builder.Build().GetFile()
+		assert.Equal(t, kubeconfig.ErrKubeconfigNameClash{
+			ClusterName: childCluster,
+			Entry:       "cluster",
+			Name:        childCluster,
+		}, err)
+	})
+
+	t.Run("Cycle in cluster map", func(t *testing.T) {
+		clusterMap := &v1alpha1.ClusterMap{
+			Map: map[string]*v1alpha1.Cluster{
+				"child": {
+					Parent:            "parent",
+					DynamicKubeConfig: true,
+				},
+				"parent": {
+					Parent:            "child",
+					DynamicKubeConfig: true,
+				},
+			},
+		}
+		builder := kubeconfig.NewBuilder().
+			WithClusterMap(clustermap.NewClusterMap(clusterMap)).
+			WithClusterName("child").
+			WithClientFactory(fakeClientFactory(t))
+		_, _, err := builder.Build().GetFile()
+		assert.Equal(t, kubeconfig.ErrClusterMapCycle{ClusterName: "child"}, err)
+	})
+
 	t.Run("No current cluster, fall to default", func(t *testing.T) {
 		clusterMap := &v1alpha1.ClusterMap{}
 		builder := kubeconfig.NewBuilder().
@@ -106,10 +261,10 @@ func TestBuilder(t *testing.T) {
 			WithClusterMap(clustermap.NewClusterMap(clusterMap)).
 			WithClusterName(childCluster)
 		kube := builder.Build()
-		// We should get a default value for cluster, as we can't find parent cluster
+		// We should get an error, as we can't find parent cluster
 		filePath, cleanup, err := kube.GetFile()
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "not implemented")
+		require.Contains(t, err.Error(), "failed to find a parent")
 		assert.Equal(t, "", filePath)
 		require.Nil(t, cleanup)
 	})
@@ -126,3 +281,47 @@ func TestBuilder(t *testing.T) {
 		assert.Equal(t, path, actualPath)
 	})
 }
+
+func TestBuilderSource(t *testing.T) {
+	clusterMap := clustermap.NewClusterMap(&v1alpha1.ClusterMap{
+		Map: map[string]*v1alpha1.Cluster{
+			"child": {
+				Parent:            "parent",
+				DynamicKubeConfig: true,
+			},
+		},
+	})
+	tests := []struct {
+		name     string
+		builder  *kubeconfig.Builder
+		expected string
+	}{
+		{
+			name:     "filepath",
+			builder:  kubeconfig.NewBuilder().WithPath("testdata/kubeconfig").WithBundle("testdata"),
+			expected: "file testdata/kubeconfig",
+		},
+		{
+			name:     "parent cluster",
+			builder:  kubeconfig.NewBuilder().WithBundle("testdata").WithClusterMap(clusterMap).WithClusterName("child"),
+			expected: "secret in the parent cluster of child",
+		},
+		{
+			name:     "bundle",
+			builder:  kubeconfig.NewBuilder().WithBundle("testdata"),
+			expected: "KubeConfig document in bundle testdata",
+		},
+		{
+			name:    "default",
+			builder: kubeconfig.NewBuilder(),
+			expected: "file " + filepath.Join(util.UserHomeDir(), config.AirshipConfigDir,
+				kubeconfig.KubeconfigDefaultFileName),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.builder.Source())
+		})
+	}
+}
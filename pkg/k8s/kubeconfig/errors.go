@@ -49,3 +49,36 @@ func (e ErrMalformedSecret) Error() string {
 		e.Namespace,
 	)
 }
+
+// ErrNoCurrentContext returned when kubeconfig extracted from the parent cluster doesn't define
+// current context along with its cluster and user
+type ErrNoCurrentContext struct {
+	ClusterName string
+}
+
+func (e ErrNoCurrentContext) Error() string {
+	return fmt.Sprintf("kubeconfig of cluster %s doesn't have a valid current context", e.ClusterName)
+}
+
+// ErrKubeconfigNameClash returned when kubeconfig extracted from the parent cluster defines cluster
+// or user with the same name as a different one defined in the kubeconfig of the parent cluster
+type ErrKubeconfigNameClash struct {
+	ClusterName string
+	// Entry is either cluster or user
+	Entry string
+	Name  string
+}
+
+func (e ErrKubeconfigNameClash) Error() string {
+	return fmt.Sprintf("kubeconfig of cluster %s defines %s %s which is already defined in the parent kubeconfig",
+		e.ClusterName, e.Entry, e.Name)
+}
+
+// ErrClusterMapCycle returned when cluster is a parent of itself through other clusters in cluster map
+type ErrClusterMapCycle struct {
+	ClusterName string
+}
+
+func (e ErrClusterMapCycle) Error() string {
+	return fmt.Sprintf("cluster %s is an ancestor of itself in cluster map", e.ClusterName)
+}
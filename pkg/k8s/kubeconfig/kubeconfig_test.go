@@ -166,6 +166,29 @@ func TestFromSecret(t *testing.T) {
 			expectedData: []byte(testValidKubeconfig),
 			err:          nil,
 		},
+		{
+			name: "custom secret name",
+			opts: &kubeconfig.FromClusterOptions{
+				ClusterName: testClusterName,
+				Namespace:   testNamespace,
+				SecretName:  "custom-kubeconfig",
+			},
+			acc: fake.WithTypedObjects(&coreV1.Secret{
+				TypeMeta: metaV1.TypeMeta{
+					Kind:       "Secret",
+					APIVersion: "v1",
+				},
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "custom-kubeconfig",
+					Namespace: testNamespace,
+				},
+				Data: map[string][]byte{
+					"value": []byte(testValidKubeconfig),
+				},
+			}),
+			expectedData: []byte(testValidKubeconfig),
+			err:          nil,
+		},
 		{
 			name: "no cluster name",
 			opts: &kubeconfig.FromClusterOptions{
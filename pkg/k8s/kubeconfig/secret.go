@@ -27,7 +27,9 @@ import (
 type FromClusterOptions struct {
 	ClusterName string
 	Namespace   string
-	Client      client.Interface
+	// SecretName is a name of the secret with kubeconfig, <ClusterName>-kubeconfig is used if not set
+	SecretName string
+	Client     client.Interface
 }
 
 // GetKubeconfigFromSecret extracts kubeconfig from secret data structure
@@ -43,7 +45,10 @@ func GetKubeconfigFromSecret(o *FromClusterOptions) ([]byte, error) {
 	}
 
 	log.Debugf("Extracting kubeconfig from secret in cluster %s(namespace: %s)", o.ClusterName, o.Namespace)
-	secretName := fmt.Sprintf("%s-kubeconfig", o.ClusterName)
+	secretName := o.SecretName
+	if secretName == "" {
+		secretName = fmt.Sprintf("%s-kubeconfig", o.ClusterName)
+	}
 	kubeCore := o.Client.ClientSet().CoreV1()
 
 	secret, err := kubeCore.Secrets(o.Namespace).Get(secretName, metav1.GetOptions{})
@@ -0,0 +1,66 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package poller
+
+import (
+	"fmt"
+	"strings"
+
+	"opendev.org/airship/airshipctl/pkg/document"
+)
+
+// ErrWaitNilBundle returned when wait executor is created without document bundle
+type ErrWaitNilBundle struct {
+}
+
+func (e ErrWaitNilBundle) Error() string {
+	return "nil bundle provided to wait executor"
+}
+
+// ErrNothingToWait returned when KubernetesWait document doesn't define any resources
+type ErrNothingToWait struct {
+}
+
+func (e ErrNothingToWait) Error() string {
+	return "no resources to wait for, specify selectors or resources in KubernetesWait document"
+}
+
+// ErrNoDocumentsSelected returned when wait selector doesn't match any phase document
+type ErrNoDocumentsSelected struct {
+	Selector document.Selector
+}
+
+func (e ErrNoDocumentsSelected) Error() string {
+	return fmt.Sprintf("no documents to wait for found by selector %s", e.Selector)
+}
+
+// ErrInvalidWaitConfig returned when KubernetesWait document has invalid configuration
+type ErrInvalidWaitConfig struct {
+	What string
+}
+
+func (e ErrInvalidWaitConfig) Error() string {
+	return fmt.Sprintf("invalid wait configuration: %s", e.What)
+}
+
+// ErrWaitTimeout returned when some resources haven't reached desired status within timeout
+type ErrWaitTimeout struct {
+	Timeout   int
+	Resources []string
+}
+
+func (e ErrWaitTimeout) Error() string {
+	return fmt.Sprintf("timed out after %ds waiting for resources: %s", e.Timeout, strings.Join(e.Resources, ", "))
+}
@@ -0,0 +1,390 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package poller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	applypoller "sigs.k8s.io/cli-utils/pkg/apply/poller"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/cluster"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/events"
+	airshipclient "opendev.org/airship/airshipctl/pkg/k8s/client"
+	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/k8s/utils"
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const (
+	// DefaultPollInterval is used if poll interval is not set in KubernetesWait document
+	DefaultPollInterval = 5 * time.Second
+)
+
+var _ ifc.Executor = &Executor{}
+
+// RegisterExecutor adds executor to phase executor registry
+func RegisterExecutor(registry map[schema.GroupVersionKind]ifc.ExecutorFactory) error {
+	obj := &v1alpha1.KubernetesWait{}
+	gvks, _, err := v1alpha1.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return err
+	}
+	registry[gvks[0]] = NewExecutor
+	return nil
+}
+
+// Executor waits for kubernetes resources to reach desired status
+type Executor struct {
+	ExecutorBundle document.Bundle
+
+	clusterName       string
+	kubeconfigContext string
+	kubeconfig        kubeconfig.Interface
+	apiObj            *v1alpha1.KubernetesWait
+	poller            applypoller.Poller
+}
+
+// NewExecutor creates instance of phase executor
+func NewExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
+	apiObj := &v1alpha1.KubernetesWait{}
+	err := cfg.ExecutorDocument.ToAPIObject(apiObj, v1alpha1.Scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := cfg.BundleFactory()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Executor{
+		ExecutorBundle:    bundle,
+		clusterName:       cfg.ClusterName,
+		kubeconfigContext: cfg.KubeconfigContext,
+		kubeconfig:        cfg.KubeConfig,
+		apiObj:            apiObj,
+	}, nil
+}
+
+// Run waits until all resources reach desired status, should be performed in separate go routine
+func (e *Executor) Run(ctx context.Context, ch chan events.Event, opts ifc.RunOptions) {
+	defer close(ch)
+
+	ids, err := e.identifiers()
+	if err != nil {
+		handleError(ch, err)
+		return
+	}
+
+	desired := e.desiredStatus()
+	ch <- events.Event{
+		Type: events.WaitType,
+		WaitEvent: events.WaitEvent{
+			Operation: events.WaitStart,
+			Message:   fmt.Sprintf("waiting for %d resources to become %s", len(ids), desired),
+		},
+	}
+
+	if opts.DryRun {
+		log.Printf("%d resources will be waited for", len(ids))
+		ch <- events.Event{
+			Type: events.WaitType,
+			WaitEvent: events.WaitEvent{
+				Operation: events.WaitEnd,
+				Message:   "dry run, resources are not waited for",
+			},
+		}
+		return
+	}
+
+	if e.poller == nil {
+		log.Debug("Getting kubeconfig file information from kubeconfig provider")
+		path, cleanup, kErr := e.kubeconfig.GetFile()
+		if kErr != nil {
+			handleError(ch, kErr)
+			return
+		}
+		defer cleanup()
+		if e.poller, err = newClusterPoller(path, e.kubeconfigContext); err != nil {
+			handleError(ch, err)
+			return
+		}
+	}
+
+	err = e.wait(ctx, ch, ids, desired)
+	var timeoutErr ErrWaitTimeout
+	switch {
+	case errors.As(err, &timeoutErr):
+		ch <- events.Event{
+			Type: events.WaitType,
+			WaitEvent: events.WaitEvent{
+				Operation: events.WaitTimeout,
+				Message:   fmt.Sprintf("%d resources haven't become %s", len(timeoutErr.Resources), desired),
+				Error:     err,
+			},
+		}
+		return
+	case errors.As(err, &errReported{}):
+		return
+	case err != nil:
+		handleError(ch, err)
+		return
+	}
+
+	ch <- events.Event{
+		Type: events.WaitType,
+		WaitEvent: events.WaitEvent{
+			Operation: events.WaitEnd,
+			Message:   fmt.Sprintf("all resources are %s", desired),
+		},
+	}
+}
+
+// errReported is returned by wait if the error has been already sent to the event channel by status poller
+type errReported struct {
+	error
+}
+
+// wait forwards status poller events until all resources reach desired status, timeout expires
+// or the run is cancelled
+func (e *Executor) wait(
+	runCtx context.Context,
+	ch chan<- events.Event,
+	ids []object.ObjMetadata,
+	desired status.Status) error {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if e.apiObj.Config.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(runCtx, time.Duration(e.apiObj.Config.Timeout)*time.Second)
+	} else {
+		ctx, cancel = context.WithCancel(runCtx)
+	}
+	defer cancel()
+
+	statuses := make(map[object.ObjMetadata]status.Status)
+	for _, id := range ids {
+		statuses[id] = status.UnknownStatus
+	}
+
+	pollCh := e.poller.Poll(ctx, ids, polling.Options{PollInterval: e.pollInterval(), UseCache: true})
+	// poller is stopped by cancelling the context, make sure it's not blocked on sending remaining events
+	defer func() {
+		go func() {
+			for range pollCh {
+			}
+		}()
+	}()
+
+	for evt := range pollCh {
+		ch <- events.Event{
+			Type:              events.StatusPollerType,
+			StatusPollerEvent: evt,
+		}
+		switch evt.EventType {
+		case pollevent.ErrorEvent:
+			return errReported{evt.Error}
+		case pollevent.ResourceUpdateEvent:
+			if evt.Resource == nil {
+				continue
+			}
+			if _, exists := statuses[evt.Resource.Identifier]; exists {
+				statuses[evt.Resource.Identifier] = evt.Resource.Status
+			}
+			if len(notReady(statuses, desired)) == 0 {
+				return nil
+			}
+		}
+	}
+	if err := runCtx.Err(); err != nil {
+		return err
+	}
+	return ErrWaitTimeout{Timeout: e.apiObj.Config.Timeout, Resources: notReady(statuses, desired)}
+}
+
+// notReady returns sorted list of resources that haven't reached desired status
+func notReady(statuses map[object.ObjMetadata]status.Status, desired status.Status) []string {
+	result := []string{}
+	for id, st := range statuses {
+		if st != desired {
+			result = append(result, fmt.Sprintf("%s/%s/%s (%s)", id.GroupKind.Kind, id.Namespace, id.Name, st))
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// identifiers returns resources selected from the phase documents along with explicitly referenced ones
+func (e *Executor) identifiers() ([]object.ObjMetadata, error) {
+	if e.ExecutorBundle == nil {
+		return nil, ErrWaitNilBundle{}
+	}
+
+	ids := []object.ObjMetadata{}
+	seen := make(map[object.ObjMetadata]bool)
+	add := func(id object.ObjMetadata) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, s := range e.apiObj.Config.Selectors {
+		selector := document.NewSelector().
+			ByGvk(s.Group, s.Version, s.Kind).
+			ByName(s.Name).
+			ByNamespace(s.Namespace).
+			ByLabel(s.LabelSelector).
+			ByAnnotation(s.AnnotationSelector)
+		docs, err := e.ExecutorBundle.Select(selector)
+		if err != nil {
+			return nil, err
+		}
+		if len(docs) == 0 {
+			return nil, ErrNoDocumentsSelected{Selector: selector}
+		}
+		for _, doc := range docs {
+			add(object.ObjMetadata{
+				GroupKind: schema.GroupKind{Group: doc.GetGroup(), Kind: doc.GetKind()},
+				Name:      doc.GetName(),
+				Namespace: doc.GetNamespace(),
+			})
+		}
+	}
+
+	for _, ref := range e.apiObj.Config.Resources {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			return nil, err
+		}
+		add(object.ObjMetadata{
+			GroupKind: schema.GroupKind{Group: gv.Group, Kind: ref.Kind},
+			Name:      ref.Name,
+			Namespace: ref.Namespace,
+		})
+	}
+
+	if len(ids) == 0 {
+		return nil, ErrNothingToWait{}
+	}
+	return ids, nil
+}
+
+func (e *Executor) desiredStatus() status.Status {
+	if e.apiObj.Config.Status == "" {
+		return status.CurrentStatus
+	}
+	return status.Status(e.apiObj.Config.Status)
+}
+
+func (e *Executor) pollInterval() time.Duration {
+	if e.apiObj.Config.PollInterval <= 0 {
+		return DefaultPollInterval
+	}
+	return time.Duration(e.apiObj.Config.PollInterval) * time.Second
+}
+
+// newClusterPoller returns status poller for the cluster defined by kubeconfig context
+func newClusterPoller(kubeconfigPath, contextName string) (applypoller.Poller, error) {
+	f := utils.FactoryFromKubeConfig(kubeconfigPath, contextName)
+	restConfig, err := f.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	restMapper, err := f.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	restClient, err := client.New(restConfig, client.Options{Mapper: restMapper})
+	if err != nil {
+		return nil, err
+	}
+	airClient, err := airshipclient.NewClientFromKubeConfig(kubeconfigPath, contextName)
+	if err != nil {
+		return nil, err
+	}
+	statusMap, err := cluster.NewStatusMap(airClient)
+	if err != nil {
+		return nil, err
+	}
+	return NewStatusPoller(restClient, restMapper, statusMap), nil
+}
+
+// Validate executor configuration and documents
+func (e *Executor) Validate() error {
+	if e.apiObj.Config.Timeout < 0 {
+		return ErrInvalidWaitConfig{What: fmt.Sprintf("timeout must not be negative, got %d", e.apiObj.Config.Timeout)}
+	}
+	if e.apiObj.Config.PollInterval < 0 {
+		return ErrInvalidWaitConfig{
+			What: fmt.Sprintf("poll interval must not be negative, got %d", e.apiObj.Config.PollInterval),
+		}
+	}
+	_, err := e.identifiers()
+	return err
+}
+
+// Details returns summary of the resources to wait for
+func (e *Executor) Details() (string, error) {
+	ids, err := e.identifiers()
+	if err != nil {
+		return "", err
+	}
+	timeout := "no timeout"
+	if e.apiObj.Config.Timeout > 0 {
+		timeout = fmt.Sprintf("%ds timeout", e.apiObj.Config.Timeout)
+	}
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		names = append(names, fmt.Sprintf("%s/%s", id.GroupKind.Kind, id.Name))
+	}
+	return fmt.Sprintf("waits for %s to become %s on %s with %s",
+		strings.Join(names, ", "), e.desiredStatus(), e.clusterName, timeout), nil
+}
+
+// Render document set
+func (e *Executor) Render(w io.Writer, o ifc.RenderOptions) error {
+	if e.ExecutorBundle == nil {
+		return ErrWaitNilBundle{}
+	}
+	bundle, err := e.ExecutorBundle.SelectBundle(o.FilterSelector)
+	if err != nil {
+		return err
+	}
+	return bundle.Write(w)
+}
+
+func handleError(ch chan<- events.Event, err error) {
+	ch <- events.Event{
+		Type: events.ErrorType,
+		ErrorEvent: events.ErrorEvent{
+			Error: err,
+		},
+	}
+}
@@ -0,0 +1,299 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package poller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const (
+	executorDoc = `
+apiVersion: airshipit.org/v1alpha1
+kind: KubernetesWait
+metadata:
+  name: wait-control-plane
+config:
+  timeout: 1
+  pollInterval: 1
+  selectors:
+  - kind: KubeadmControlPlane
+  resources:
+  - apiVersion: metal3.io/v1alpha1
+    kind: BareMetalHost
+    namespace: default
+    name: node01
+`
+	bundleDocs = `
+apiVersion: controlplane.cluster.x-k8s.io/v1alpha3
+kind: KubeadmControlPlane
+metadata:
+  name: cluster-controlplane
+  namespace: default
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unrelated
+  namespace: default
+`
+)
+
+var (
+	kcpID = object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "controlplane.cluster.x-k8s.io", Kind: "KubeadmControlPlane"},
+		Name:      "cluster-controlplane",
+		Namespace: "default",
+	}
+	bmhID = object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "metal3.io", Kind: "BareMetalHost"},
+		Name:      "node01",
+		Namespace: "default",
+	}
+)
+
+// fakePoller sends predefined events and then waits until context is cancelled
+type fakePoller struct {
+	events []pollevent.Event
+}
+
+func (fp fakePoller) Poll(ctx context.Context, _ []object.ObjMetadata, _ polling.Options) <-chan pollevent.Event {
+	ch := make(chan pollevent.Event)
+	go func() {
+		defer close(ch)
+		for _, e := range fp.events {
+			select {
+			case ch <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+		<-ctx.Done()
+	}()
+	return ch
+}
+
+func updateEvent(id object.ObjMetadata, st status.Status) pollevent.Event {
+	return pollevent.Event{
+		EventType: pollevent.ResourceUpdateEvent,
+		Resource:  &pollevent.ResourceStatus{Identifier: id, Status: st},
+	}
+}
+
+func testExecutor(t *testing.T, execDoc string) *Executor {
+	doc, err := document.NewDocumentFromBytes([]byte(execDoc))
+	require.NoError(t, err)
+	executor, err := NewExecutor(ifc.ExecutorConfig{
+		ClusterName:      "target-cluster",
+		ExecutorDocument: doc,
+		BundleFactory: func() (document.Bundle, error) {
+			return document.NewBundleFromBytes([]byte(bundleDocs))
+		},
+	})
+	require.NoError(t, err)
+	e, ok := executor.(*Executor)
+	require.True(t, ok)
+	return e
+}
+
+func TestRegisterExecutor(t *testing.T) {
+	registry := make(map[schema.GroupVersionKind]ifc.ExecutorFactory)
+	expectedGVK := schema.GroupVersionKind{
+		Group:   "airshipit.org",
+		Version: "v1alpha1",
+		Kind:    "KubernetesWait",
+	}
+	err := RegisterExecutor(registry)
+	require.NoError(t, err)
+
+	_, found := registry[expectedGVK]
+	assert.True(t, found)
+}
+
+func TestExecutorRun(t *testing.T) {
+	testErr := fmt.Errorf("poll error")
+	tests := []struct {
+		name           string
+		pollEvents     []pollevent.Event
+		dryRun         bool
+		expectedTypes  []events.Type
+		expectedErr    error
+		expectedWaitOp events.WaitOperation
+	}{
+		{
+			name: "all resources become current",
+			pollEvents: []pollevent.Event{
+				updateEvent(kcpID, status.InProgressStatus),
+				updateEvent(bmhID, status.CurrentStatus),
+				updateEvent(kcpID, status.CurrentStatus),
+			},
+			expectedTypes: []events.Type{
+				events.WaitType,
+				events.StatusPollerType,
+				events.StatusPollerType,
+				events.StatusPollerType,
+				events.WaitType,
+			},
+		},
+		{
+			name:          "dry run",
+			dryRun:        true,
+			expectedTypes: []events.Type{events.WaitType, events.WaitType},
+		},
+		{
+			name: "timeout",
+			pollEvents: []pollevent.Event{
+				updateEvent(kcpID, status.CurrentStatus),
+				updateEvent(bmhID, status.InProgressStatus),
+			},
+			expectedTypes: []events.Type{
+				events.WaitType,
+				events.StatusPollerType,
+				events.StatusPollerType,
+				events.WaitType,
+			},
+			expectedErr: ErrWaitTimeout{
+				Timeout:   1,
+				Resources: []string{"BareMetalHost/default/node01 (InProgress)"},
+			},
+		},
+		{
+			name: "poller error",
+			pollEvents: []pollevent.Event{
+				{EventType: pollevent.ErrorEvent, Error: testErr},
+			},
+			expectedTypes: []events.Type{
+				events.WaitType,
+				events.StatusPollerType,
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			executor := testExecutor(t, executorDoc)
+			executor.poller = fakePoller{events: tt.pollEvents}
+			ch := make(chan events.Event)
+			go executor.Run(context.Background(), ch, ifc.RunOptions{DryRun: tt.dryRun})
+
+			var actualTypes []events.Type
+			var actualErr error
+			for evt := range ch {
+				actualTypes = append(actualTypes, evt.Type)
+				if err := events.ErrorOf(evt); err != nil {
+					actualErr = err
+				}
+			}
+			assert.Equal(t, tt.expectedTypes, actualTypes)
+			assert.Equal(t, tt.expectedErr, actualErr)
+		})
+	}
+}
+
+func TestExecutorRunCancelled(t *testing.T) {
+	executor := testExecutor(t, executorDoc)
+	executor.poller = fakePoller{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ch := make(chan events.Event)
+	go executor.Run(ctx, ch, ifc.RunOptions{})
+
+	var actualErr error
+	for evt := range ch {
+		if evt.Type == events.ErrorType {
+			actualErr = evt.ErrorEvent.Error
+		}
+	}
+	assert.Equal(t, context.Canceled, actualErr)
+}
+
+func TestExecutorValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		execDoc     string
+		expectedErr error
+	}{
+		{
+			name:    "success",
+			execDoc: executorDoc,
+		},
+		{
+			name: "nothing to wait",
+			execDoc: `
+apiVersion: airshipit.org/v1alpha1
+kind: KubernetesWait
+metadata:
+  name: wait
+`,
+			expectedErr: ErrNothingToWait{},
+		},
+		{
+			name: "selector matches no documents",
+			execDoc: `
+apiVersion: airshipit.org/v1alpha1
+kind: KubernetesWait
+metadata:
+  name: wait
+config:
+  selectors:
+  - kind: BareMetalHost
+`,
+			expectedErr: ErrNoDocumentsSelected{Selector: document.NewSelector().ByKind("BareMetalHost")},
+		},
+		{
+			name: "negative timeout",
+			execDoc: `
+apiVersion: airshipit.org/v1alpha1
+kind: KubernetesWait
+metadata:
+  name: wait
+config:
+  timeout: -1
+`,
+			expectedErr: ErrInvalidWaitConfig{What: "timeout must not be negative, got -1"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			executor := testExecutor(t, tt.execDoc)
+			assert.Equal(t, tt.expectedErr, executor.Validate())
+		})
+	}
+}
+
+func TestExecutorDetails(t *testing.T) {
+	executor := testExecutor(t, executorDoc)
+	details, err := executor.Details()
+	require.NoError(t, err)
+	assert.Equal(t, "waits for KubeadmControlPlane/cluster-controlplane, BareMetalHost/node01 "+
+		"to become Current on target-cluster with 1s timeout", details)
+}
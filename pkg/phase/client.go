@@ -15,21 +15,32 @@
 package phase
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	"io"
 	"path/filepath"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/bootstrap/isogen"
+	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
 	clusterctl "opendev.org/airship/airshipctl/pkg/clusterctl/client"
+	"opendev.org/airship/airshipctl/pkg/container"
 	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/events"
 	"opendev.org/airship/airshipctl/pkg/k8s/applier"
 	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/k8s/poller"
 	"opendev.org/airship/airshipctl/pkg/k8s/utils"
 	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/phase/history"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/pkg/phase/plugin"
+	"opendev.org/airship/airshipctl/pkg/remote"
+	"opendev.org/airship/airshipctl/pkg/util"
 )
 
 // ExecutorRegistry returns map with executor factories
@@ -45,9 +56,22 @@ func DefaultExecutorRegistry() map[schema.GroupVersionKind]ifc.ExecutorFactory {
 	if err := applier.RegisterExecutor(execMap); err != nil {
 		log.Fatal(ErrExecutorRegistration{ExecutorName: "kubernetes-apply", Err: err})
 	}
+	if err := poller.RegisterExecutor(execMap); err != nil {
+		log.Fatal(ErrExecutorRegistration{ExecutorName: "kubernetes-wait", Err: err})
+	}
 	if err := isogen.RegisterExecutor(execMap); err != nil {
 		log.Fatal(ErrExecutorRegistration{ExecutorName: "isogen", Err: err})
 	}
+	if err := container.RegisterExecutor(execMap); err != nil {
+		log.Fatal(ErrExecutorRegistration{ExecutorName: "generic-container", Err: err})
+	}
+	if err := remote.RegisterExecutor(execMap); err != nil {
+		log.Fatal(ErrExecutorRegistration{ExecutorName: "baremetal-manager", Err: err})
+	}
+	// plugins are registered last, so they can't replace built-in executors
+	if err := plugin.RegisterExecutors(execMap, plugin.Path()); err != nil {
+		log.Fatal(ErrExecutorRegistration{ExecutorName: "executor-plugins", Err: err})
+	}
 	return execMap
 }
 
@@ -59,6 +83,9 @@ type phase struct {
 	apiObj    *v1alpha1.Phase
 	registry  ExecutorRegistry
 	processor events.EventProcessor
+	recorder  history.Recorder
+	// hookOf holds names of the phases this phase is executed as a hook of
+	hookOf []string
 }
 
 // Executor returns executor interface associated with the phase
@@ -88,47 +115,172 @@ func (p *phase) Executor() (ifc.Executor, error) {
 		return nil, err
 	}
 
-	wd, err := p.helper.WorkDir()
+	kubeconfBuilder, err := p.kubeconfigBuilder(cMap)
 	if err != nil {
 		return nil, err
 	}
-	kubeconf := kubeconfig.NewBuilder().
-		WithBundle(p.helper.PhaseRoot()).
-		WithClusterMap(cMap).
-		WithClusterName(p.apiObj.ClusterName).
-		WithTempRoot(wd).
-		Build()
+	kubeconf := kubeconfBuilder.Build()
+
+	kubeconfigContext := ""
+	if p.apiObj.ClusterName != "" {
+		if kubeconfigContext, err = cMap.ClusterKubeconfigContext(p.apiObj.ClusterName); err != nil {
+			return nil, err
+		}
+	}
 
 	return executorFactory(
 		ifc.ExecutorConfig{
-			ClusterMap:       cMap,
-			BundleFactory:    bundleFactory,
-			PhaseName:        p.apiObj.Name,
-			KubeConfig:       kubeconf,
-			ExecutorDocument: executorDoc,
-			ClusterName:      p.apiObj.ClusterName,
-			Helper:           p.helper,
+			ClusterMap:        cMap,
+			BundleFactory:     bundleFactory,
+			PhaseName:         p.apiObj.Name,
+			KubeConfig:        kubeconf,
+			ExecutorDocument:  executorDoc,
+			ClusterName:       p.apiObj.ClusterName,
+			KubeconfigContext: kubeconfigContext,
+			Helper:            p.helper,
+			AirshipConfig:     p.helper.AirshipConfig(),
 		})
 }
 
-// Run runs the phase via executor
-func (p *phase) Run(ro ifc.RunOptions) error {
+// kubeconfigBuilder returns kubeconfig builder for the phase cluster
+func (p *phase) kubeconfigBuilder(cMap clustermap.ClusterMap) (*kubeconfig.Builder, error) {
+	wd, err := p.helper.WorkDir()
+	if err != nil {
+		return nil, err
+	}
+	return kubeconfig.NewBuilder().
+		WithBundle(p.helper.PhaseRoot()).
+		WithClusterMap(cMap).
+		WithClusterName(p.apiObj.ClusterName).
+		WithTempRoot(wd), nil
+}
+
+// Run runs the phase via executor along with its pre-run and post-run hooks, ErrPhaseCancelled
+// is returned if the phase has failed because the context was cancelled or the run timeout has expired
+func (p *phase) Run(ctx context.Context, ro ifc.RunOptions) (err error) {
+	if p.recorder != nil {
+		start := time.Now()
+		defer func() {
+			p.record(start, ro, err)
+		}()
+	}
+
+	if ro.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, ro.Timeout)
+		defer cancel()
+	}
+
 	executor, err := p.Executor()
 	if err != nil {
 		return err
 	}
 	ch := make(chan events.Event)
 
+	if ro.Diff {
+		// phase hooks are not executed, since nothing is changed by the diff
+		differ, ok := executor.(ifc.DiffExecutor)
+		if !ok {
+			return ErrDiffNotSupported{PhaseName: p.apiObj.Name, ExecutorKind: p.apiObj.Config.ExecutorRef.Kind}
+		}
+		go differ.Diff(ctx, ch)
+	} else {
+		go func() {
+			p.runWithHooks(ctx, executor, ch, ro)
+		}()
+	}
+	err = p.processor.Process(p.stampEvents(ch))
+	if err != nil && ctx.Err() != nil {
+		return ErrPhaseCancelled{PhaseName: p.apiObj.Name, Err: ctx.Err()}
+	}
+	return err
+}
+
+// stampEvents sets the time, phase and cluster name of the events received from the phase run,
+// events of the phases executed as hooks keep the name of their own phase
+func (p *phase) stampEvents(in <-chan events.Event) <-chan events.Event {
+	out := make(chan events.Event)
 	go func() {
-		executor.Run(ch, ro)
+		defer close(out)
+		for e := range in {
+			if e.PhaseName == "" {
+				e.PhaseName = p.apiObj.Name
+				e.ClusterName = p.apiObj.ClusterName
+			}
+			if e.Timestamp.IsZero() {
+				e.Timestamp = time.Now()
+			}
+			out <- e
+		}
 	}()
-	return p.processor.Process(ch)
+	return out
 }
 
-// Validate makes sure that phase is properly configured
-// TODO implement this
+// record saves the result of the phase run to the history, failure to save
+// the record is logged and doesn't affect the result of the run
+func (p *phase) record(start time.Time, ro ifc.RunOptions, runErr error) {
+	r := history.Record{
+		PhaseName:      p.apiObj.Name,
+		PhaseNamespace: p.apiObj.Namespace,
+		ClusterName:    p.apiObj.ClusterName,
+		StartTime:      start,
+		EndTime:        time.Now(),
+		Result:         history.ResultSucceeded,
+		Revision:       history.Revision(p.helper.TargetPath()),
+		DryRun:         ro.DryRun || ro.Diff,
+	}
+	if runErr != nil {
+		r.Result = history.ResultFailed
+		if e, ok := runErr.(events.ErrEventReceived); ok {
+			for _, eventErr := range e.Errors {
+				r.Errors = append(r.Errors, eventErr.Error())
+			}
+		} else {
+			r.Errors = []string{runErr.Error()}
+		}
+	}
+	if err := p.recorder.Add(r); err != nil {
+		log.Printf("Failed to save phase %s run to history: %v", p.apiObj.Name, err)
+	}
+}
+
+// Validate makes sure that phase is properly configured: executor document and its
+// factory can be found, document entrypoint can be built, phase cluster is defined
+// in cluster map, phase hooks, retry policy and executor configuration are valid
 func (p *phase) Validate() error {
-	return nil
+	if p.apiObj.ClusterName != "" {
+		apiMap, err := p.helper.ClusterMapAPIobj()
+		if err != nil {
+			return err
+		}
+		if _, exists := apiMap.Map[p.apiObj.ClusterName]; !exists {
+			return clustermap.ErrClusterNotInMap{Child: p.apiObj.ClusterName, Map: apiMap}
+		}
+	}
+
+	if p.apiObj.Config.DocumentEntryPoint != "" {
+		docRoot, err := p.DocumentRoot()
+		if err != nil {
+			return err
+		}
+		if _, err = document.NewBundleByPath(docRoot); err != nil {
+			return err
+		}
+	}
+
+	if err := p.validateHooks(); err != nil {
+		return err
+	}
+
+	if err := p.validateRetry(); err != nil {
+		return err
+	}
+
+	executor, err := p.Executor()
+	if err != nil {
+		return err
+	}
+	return executor.Validate()
 }
 
 // Render executor documents
@@ -154,11 +306,60 @@ func (p *phase) DocumentRoot() (string, error) {
 	return filepath.Join(targetPath, p.apiObj.Config.DocumentEntryPoint), nil
 }
 
-// Details returns description of the phase
-// TODO implement this: add details field to api.Phase and method to executor and combine them here
-// to give a clear understanding to user of what this phase is about
+// Details returns description of the phase combined with executor summary,
+// document root, kubeconfig source and parent cluster of the phase
 func (p *phase) Details() (string, error) {
-	return "", nil
+	docRoot, err := p.DocumentRoot()
+	if _, noEntrypoint := err.(ErrDocumentEntrypointNotDefined); err != nil && !noEntrypoint {
+		return "", err
+	}
+
+	cMap, err := p.helper.ClusterMap()
+	if err != nil {
+		return "", err
+	}
+	parent := ""
+	if p.apiObj.ClusterName != "" {
+		// parent cluster is not defined for top level clusters
+		if parent, err = cMap.ParentCluster(p.apiObj.ClusterName); err != nil {
+			log.Debugf("Parent cluster is not found for phase %s: %v", p.apiObj.Name, err)
+		}
+	}
+
+	kubeconfBuilder, err := p.kubeconfigBuilder(cMap)
+	if err != nil {
+		return "", err
+	}
+
+	executor, err := p.Executor()
+	if err != nil {
+		return "", err
+	}
+	summary, err := executor.Details()
+	if err != nil {
+		return "", err
+	}
+
+	executorRef := ""
+	if ref := p.apiObj.Config.ExecutorRef; ref != nil {
+		executorRef = fmt.Sprintf("%s/%s", ref.Kind, ref.Name)
+	}
+
+	buf := &bytes.Buffer{}
+	tw := util.NewTabWriter(buf)
+	fmt.Fprintf(tw, "Name:\t%s\n", p.apiObj.Name)
+	fmt.Fprintf(tw, "Namespace:\t%s\n", p.apiObj.Namespace)
+	fmt.Fprintf(tw, "Description:\t%s\n", p.apiObj.Description)
+	fmt.Fprintf(tw, "Executor:\t%s\n", executorRef)
+	fmt.Fprintf(tw, "Summary:\t%s\n", summary)
+	fmt.Fprintf(tw, "Cluster:\t%s\n", p.apiObj.ClusterName)
+	fmt.Fprintf(tw, "Parent cluster:\t%s\n", parent)
+	fmt.Fprintf(tw, "Document root:\t%s\n", docRoot)
+	fmt.Fprintf(tw, "Kubeconfig source:\t%s\n", kubeconfBuilder.Source())
+	if err = tw.Flush(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
 }
 
 var _ ifc.Client = &client{}
@@ -168,6 +369,7 @@ type client struct {
 
 	registry      ExecutorRegistry
 	processorFunc ProcessorFunc
+	recorder      history.Recorder
 }
 
 // ProcessorFunc that returns processor interface
@@ -190,6 +392,13 @@ func InjectRegistry(registry ExecutorRegistry) Option {
 	}
 }
 
+// InjectHistory is an option that allows to save results of phase runs to the history
+func InjectHistory(recorder history.Recorder) Option {
+	return func(c *client) {
+		c.recorder = recorder
+	}
+}
+
 // NewClient returns implementation of phase Client interface
 func NewClient(helper ifc.Helper, opts ...Option) ifc.Client {
 	c := &client{Helper: helper}
@@ -216,6 +425,7 @@ func (c *client) PhaseByID(id ifc.ID) (ifc.Phase, error) {
 		helper:    c.Helper,
 		processor: c.processorFunc(),
 		registry:  c.registry,
+		recorder:  c.recorder,
 	}
 	return phase, nil
 }
@@ -226,6 +436,7 @@ func (c *client) PhaseByAPIObj(phaseObj *v1alpha1.Phase) (ifc.Phase, error) {
 		helper:    c.Helper,
 		processor: c.processorFunc(),
 		registry:  c.registry,
+		recorder:  c.recorder,
 	}
 	return phase, nil
 }
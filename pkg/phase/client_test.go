@@ -15,17 +15,22 @@
 package phase_test
 
 import (
+	"context"
+	"errors"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/events"
 	"opendev.org/airship/airshipctl/pkg/phase"
+	"opendev.org/airship/airshipctl/pkg/phase/history"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
 )
 
@@ -124,7 +129,7 @@ func TestPhaseRun(t *testing.T) {
 			require.NotNil(t, client)
 			p, err := client.PhaseByID(tt.phaseID)
 			require.NotNil(t, client)
-			err = p.Run(ifc.RunOptions{DryRun: true})
+			err = p.Run(context.Background(), ifc.RunOptions{DryRun: true})
 			if tt.errContains != "" {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errContains)
@@ -135,6 +140,146 @@ func TestPhaseRun(t *testing.T) {
 	}
 }
 
+type fakeRecorder struct {
+	records []history.Record
+}
+
+func (r *fakeRecorder) Add(record history.Record) error {
+	r.records = append(r.records, record)
+	return nil
+}
+
+func TestPhaseRunHistory(t *testing.T) {
+	tests := []struct {
+		name           string
+		phaseID        ifc.ID
+		expectedResult history.Result
+		errContains    string
+	}{
+		{
+			name:           "Success run is recorded",
+			phaseID:        ifc.ID{Name: "capi_init"},
+			expectedResult: history.ResultSucceeded,
+		},
+		{
+			name:           "Failed run is recorded",
+			phaseID:        ifc.ID{Name: "some_phase"},
+			expectedResult: history.ResultFailed,
+			errContains:    "found no documents",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			helper, err := phase.NewHelper(testConfig(t))
+			require.NoError(t, err)
+			recorder := &fakeRecorder{}
+			client := phase.NewClient(helper,
+				phase.InjectRegistry(fakeRegistry),
+				phase.InjectHistory(recorder))
+			p, err := client.PhaseByID(tt.phaseID)
+			require.NoError(t, err)
+			runErr := p.Run(context.Background(), ifc.RunOptions{DryRun: true})
+
+			require.Len(t, recorder.records, 1)
+			record := recorder.records[0]
+			assert.Equal(t, tt.phaseID.Name, record.PhaseName)
+			assert.Equal(t, tt.expectedResult, record.Result)
+			assert.True(t, record.DryRun)
+			assert.False(t, record.EndTime.Before(record.StartTime))
+			if tt.errContains != "" {
+				require.Error(t, runErr)
+				require.Len(t, record.Errors, 1)
+				assert.Contains(t, record.Errors[0], tt.errContains)
+			} else {
+				require.NoError(t, runErr)
+				assert.Empty(t, record.Errors)
+			}
+		})
+	}
+}
+
+func TestPhaseValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		phaseObj    *v1alpha1.Phase
+		phaseID     ifc.ID
+		errContains string
+	}{
+		{
+			name:    "Success fake executor",
+			phaseID: ifc.ID{Name: "capi_init"},
+		},
+		{
+			name:        "Error executor doc doesn't exist",
+			phaseID:     ifc.ID{Name: "some_phase"},
+			errContains: "found no documents",
+		},
+		{
+			name: "Error cluster is not in cluster map",
+			phaseObj: &v1alpha1.Phase{
+				ObjectMeta: metav1.ObjectMeta{Name: "capi_init", ClusterName: "unknown-cluster"},
+			},
+			errContains: "cluster unknown-cluster is not defined",
+		},
+		{
+			name: "Error document entrypoint can't be built",
+			phaseObj: &v1alpha1.Phase{
+				ObjectMeta: metav1.ObjectMeta{Name: "capi_init"},
+				Config:     v1alpha1.PhaseConfig{DocumentEntryPoint: "does/not/exist"},
+			},
+			errContains: "does/not/exist",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			helper, err := phase.NewHelper(testConfig(t))
+			require.NoError(t, err)
+			client := phase.NewClient(helper, phase.InjectRegistry(fakeRegistry))
+			var p ifc.Phase
+			if tt.phaseObj != nil {
+				p, err = client.PhaseByAPIObj(tt.phaseObj)
+			} else {
+				p, err = client.PhaseByID(tt.phaseID)
+			}
+			require.NoError(t, err)
+			err = p.Validate()
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPhaseDetails(t *testing.T) {
+	helper, err := phase.NewHelper(testConfig(t))
+	require.NoError(t, err)
+	client := phase.NewClient(helper, phase.InjectRegistry(fakeRegistry))
+
+	p, err := client.PhaseByID(ifc.ID{Name: "capi_init"})
+	require.NoError(t, err)
+	details, err := p.Details()
+	require.NoError(t, err)
+	assert.Contains(t, details, "capi_init")
+	assert.Contains(t, details, "Initialize cluster-api providers")
+	assert.Contains(t, details, "Clusterctl/clusterctl-v1")
+	assert.Contains(t, details, "fake executor details")
+	assert.Contains(t, details, "valid_site/phases")
+	assert.Contains(t, details, "KubeConfig document in bundle")
+
+	p, err = client.PhaseByID(ifc.ID{Name: "some_phase"})
+	require.NoError(t, err)
+	_, err = p.Details()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "found no documents")
+}
+
 // TODO develop tests, when we add phase object validation
 func TestClientByAPIObj(t *testing.T) {
 	helper, err := phase.NewHelper(testConfig(t))
@@ -168,6 +313,131 @@ func fakeRegistry() map[schema.GroupVersionKind]ifc.ExecutorFactory {
 	}
 }
 
+// blockingRegistry returns executors which are running until the context is cancelled
+func blockingRegistry() map[schema.GroupVersionKind]ifc.ExecutorFactory {
+	gvk := schema.GroupVersionKind{
+		Group:   "airshipit.org",
+		Version: "v1alpha1",
+		Kind:    "Clusterctl",
+	}
+	return map[schema.GroupVersionKind]ifc.ExecutorFactory{
+		gvk: func(ifc.ExecutorConfig) (ifc.Executor, error) {
+			return blockingExecutor{}, nil
+		},
+	}
+}
+
+type blockingExecutor struct {
+	fakeExecutor
+}
+
+func (e blockingExecutor) Run(ctx context.Context, ch chan events.Event, _ ifc.RunOptions) {
+	defer close(ch)
+	<-ctx.Done()
+	ch <- events.Event{
+		Type:       events.ErrorType,
+		ErrorEvent: events.ErrorEvent{Error: ctx.Err()},
+	}
+}
+
+func TestPhaseRunCancelled(t *testing.T) {
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		runOptions  ifc.RunOptions
+		expectedErr error
+	}{
+		{
+			name:        "Error context is cancelled",
+			ctx:         cancelledCtx,
+			expectedErr: phase.ErrPhaseCancelled{PhaseName: "capi_init", Err: context.Canceled},
+		},
+		{
+			name:        "Error timeout has expired",
+			ctx:         context.Background(),
+			runOptions:  ifc.RunOptions{Timeout: time.Millisecond},
+			expectedErr: phase.ErrPhaseCancelled{PhaseName: "capi_init", Err: context.DeadlineExceeded},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			helper, err := phase.NewHelper(testConfig(t))
+			require.NoError(t, err)
+			client := phase.NewClient(helper, phase.InjectRegistry(blockingRegistry))
+			p, err := client.PhaseByID(ifc.ID{Name: "capi_init"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedErr, p.Run(tt.ctx, tt.runOptions))
+		})
+	}
+}
+
+// diffRegistry returns executors which support diff and fail if phase is run
+func diffRegistry() map[schema.GroupVersionKind]ifc.ExecutorFactory {
+	gvk := schema.GroupVersionKind{
+		Group:   "airshipit.org",
+		Version: "v1alpha1",
+		Kind:    "Clusterctl",
+	}
+	return map[schema.GroupVersionKind]ifc.ExecutorFactory{
+		gvk: func(ifc.ExecutorConfig) (ifc.Executor, error) {
+			return diffExecutor{}, nil
+		},
+	}
+}
+
+var _ ifc.DiffExecutor = diffExecutor{}
+
+type diffExecutor struct {
+	fakeExecutor
+}
+
+func (e diffExecutor) Run(_ context.Context, ch chan events.Event, _ ifc.RunOptions) {
+	defer close(ch)
+	ch <- events.Event{
+		Type:       events.ErrorType,
+		ErrorEvent: events.ErrorEvent{Error: errors.New("phase must not be run")},
+	}
+}
+
+func (e diffExecutor) Diff(_ context.Context, ch chan events.Event) {
+	defer close(ch)
+}
+
+func TestPhaseRunDiff(t *testing.T) {
+	tests := []struct {
+		name         string
+		registryFunc phase.ExecutorRegistry
+		expectedErr  error
+	}{
+		{
+			name:         "Success diff is supported",
+			registryFunc: diffRegistry,
+		},
+		{
+			name:         "Error diff is not supported",
+			registryFunc: fakeRegistry,
+			expectedErr:  phase.ErrDiffNotSupported{PhaseName: "capi_init", ExecutorKind: "Clusterctl"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			helper, err := phase.NewHelper(testConfig(t))
+			require.NoError(t, err)
+			client := phase.NewClient(helper, phase.InjectRegistry(tt.registryFunc))
+			p, err := client.PhaseByID(ifc.ID{Name: "capi_init"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedErr, p.Run(context.Background(), ifc.RunOptions{Diff: true}))
+		})
+	}
+}
+
 func TestDocumentRoot(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -219,10 +489,14 @@ func (e fakeExecutor) Render(_ io.Writer, _ ifc.RenderOptions) error {
 	return nil
 }
 
-func (e fakeExecutor) Run(ch chan events.Event, _ ifc.RunOptions) {
+func (e fakeExecutor) Run(_ context.Context, ch chan events.Event, _ ifc.RunOptions) {
 	defer close(ch)
 }
 
 func (e fakeExecutor) Validate() error {
 	return nil
 }
+
+func (e fakeExecutor) Details() (string, error) {
+	return "fake executor details", nil
+}
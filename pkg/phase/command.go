@@ -15,18 +15,36 @@
 package phase
 
 import (
+	"context"
+	"fmt"
 	"io"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/k8s/utils"
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/phase/history"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
 )
 
 // RunFlags options for phase run command
 type RunFlags struct {
-	DryRun  bool
+	DryRun bool
+	// Diff shows changes the phase would make to the cluster instead of running it
+	Diff    bool
 	PhaseID ifc.ID
+	// Timeout is a maximum duration of the phase run, phase is not limited in time if it is not set
+	Timeout time.Duration
+	// Output is a format of the printed events, one of text or json
+	Output string
+	// EventsFile is a path to the file where all events of the phase run are recorded
+	EventsFile string
 }
 
 // RunCommand phase run command
@@ -37,6 +55,16 @@ type RunCommand struct {
 
 // RunE runs the phase
 func (c *RunCommand) RunE() error {
+	processor, err := eventProcessor(c.Options.Output)
+	if err != nil {
+		return err
+	}
+	processor, closeFile, err := recordEvents(processor, c.Options.EventsFile)
+	if err != nil {
+		return err
+	}
+	defer closeFile()
+
 	cfg, err := c.Factory()
 	if err != nil {
 		return err
@@ -47,13 +75,87 @@ func (c *RunCommand) RunE() error {
 		return err
 	}
 
-	client := NewClient(helper)
+	wd, err := helper.WorkDir()
+	if err != nil {
+		return err
+	}
+
+	client := NewClient(helper,
+		InjectProcessor(processor),
+		InjectHistory(history.NewStore(wd)))
 
 	phase, err := client.PhaseByID(c.Options.PhaseID)
 	if err != nil {
 		return err
 	}
-	return phase.Run(ifc.RunOptions{DryRun: c.Options.DryRun})
+
+	ctx, cancel := interruptContext()
+	defer cancel()
+	return phase.Run(ctx, ifc.RunOptions{
+		DryRun:  c.Options.DryRun,
+		Diff:    c.Options.Diff,
+		Timeout: c.Options.Timeout,
+	})
+}
+
+// interruptContext returns a context which is cancelled when interrupt or termination signal is received,
+// so running phases are stopped and the resources they use are cleaned up before airshipctl exits
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		defer signal.Stop(sigCh)
+		select {
+		case sig := <-sigCh:
+			log.Printf("Received %s signal, cancelling", sig)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// Supported output formats of phase and plan run events
+const (
+	TextEventFormat = "text"
+	JSONEventFormat = "json"
+)
+
+// eventProcessor returns a function creating processor which prints events in the requested format
+func eventProcessor(format string) (ProcessorFunc, error) {
+	switch format {
+	case "", TextEventFormat:
+		return defaultProcessor, nil
+	case JSONEventFormat:
+		// log messages are moved to stderr, so that stdout contains nothing but JSON lines
+		log.Init(log.DebugEnabled(), os.Stderr)
+		return func() events.EventProcessor {
+			return events.NewJSONProcessor(utils.Streams().Out)
+		}, nil
+	default:
+		return nil, ErrInvalidEventFormat{RequestedFormat: format}
+	}
+}
+
+// recordEvents makes processors write all events to the events file, so that they can be replayed later.
+// Events are appended if the file exists, returned function closes the file
+func recordEvents(procFunc ProcessorFunc, path string) (ProcessorFunc, func(), error) {
+	if path == "" {
+		return procFunc, func() {}, nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, nil, err
+	}
+	closeFile := func() {
+		if closeErr := f.Close(); closeErr != nil {
+			log.Printf("Failed to close events file %s: %v", path, closeErr)
+		}
+	}
+	return func() events.EventProcessor {
+		return events.NewRecorder(f, procFunc())
+	}, closeFile, nil
 }
 
 // PlanCommand plan command
@@ -82,6 +184,311 @@ func (c *PlanCommand) RunE() error {
 	return PrintPlan(plan, c.Writer)
 }
 
+// PlanRunFlags options for plan run command
+type PlanRunFlags struct {
+	DryRun      bool
+	StartAt     string
+	StopAfter   string
+	Concurrency int
+	// Timeout is a maximum duration of each phase run
+	Timeout time.Duration
+	// Output is a format of the printed events, one of text or json,
+	// execution summary is printed in text format only
+	Output string
+	// EventsFile is a path to the file where events of all phases are recorded
+	EventsFile string
+}
+
+// PlanRunCommand plan run command
+type PlanRunCommand struct {
+	Options PlanRunFlags
+	Factory config.Factory
+	Writer  io.Writer
+}
+
+// RunE runs all phases defined in the phase plan and prints execution summary
+func (c *PlanRunCommand) RunE() error {
+	processor, err := eventProcessor(c.Options.Output)
+	if err != nil {
+		return err
+	}
+	processor, closeFile, err := recordEvents(processor, c.Options.EventsFile)
+	if err != nil {
+		return err
+	}
+	defer closeFile()
+
+	cfg, err := c.Factory()
+	if err != nil {
+		return err
+	}
+
+	helper, err := NewHelper(cfg)
+	if err != nil {
+		return err
+	}
+
+	plan, err := helper.Plan()
+	if err != nil {
+		return err
+	}
+
+	wd, err := helper.WorkDir()
+	if err != nil {
+		return err
+	}
+
+	// events of the phases executed simultaneously are printed by a single processor
+	merger := events.NewMerger(processor())
+	client := NewClient(helper,
+		InjectProcessor(merger.Processor),
+		InjectHistory(history.NewStore(wd)))
+	ctx, cancel := interruptContext()
+	defer cancel()
+	results, runErr := RunPlan(ctx, client, plan, PlanRunOptions{
+		RunOptions:  ifc.RunOptions{DryRun: c.Options.DryRun, Timeout: c.Options.Timeout},
+		StartAt:     c.Options.StartAt,
+		StopAfter:   c.Options.StopAfter,
+		Concurrency: c.Options.Concurrency,
+	})
+	mergeErr := merger.Close()
+	if runErr == nil {
+		runErr = mergeErr
+	}
+	if results != nil && c.Options.Output != JSONEventFormat {
+		if err = PrintPlanResults(results, c.Writer); err != nil {
+			return err
+		}
+	}
+	return runErr
+}
+
+// ListFlags options for phase list command
+type ListFlags struct {
+	// Label filters phases by label selector
+	Label string
+	// ClusterName filters phases by cluster name
+	ClusterName string
+	// Output is an output format, one of table, yaml or json
+	Output string
+}
+
+// ListCommand phase list command
+type ListCommand struct {
+	Options ListFlags
+	Factory config.Factory
+	Writer  io.Writer
+}
+
+// RunE prints phases defined in the manifest
+func (c *ListCommand) RunE() error {
+	cfg, err := c.Factory()
+	if err != nil {
+		return err
+	}
+
+	helper, err := NewHelper(cfg)
+	if err != nil {
+		return err
+	}
+
+	phases, err := helper.ListPhases(ifc.ListPhaseOptions{
+		Label:       c.Options.Label,
+		ClusterName: c.Options.ClusterName,
+	})
+	if err != nil {
+		return err
+	}
+	return PrintPhaseList(phases, c.Options.Output, c.Writer)
+}
+
+// DescribeFlags options for phase describe command
+type DescribeFlags struct {
+	PhaseID ifc.ID
+}
+
+// DescribeCommand phase describe command
+type DescribeCommand struct {
+	Options DescribeFlags
+	Factory config.Factory
+	Writer  io.Writer
+}
+
+// RunE prints details of the phase
+func (c *DescribeCommand) RunE() error {
+	cfg, err := c.Factory()
+	if err != nil {
+		return err
+	}
+
+	helper, err := NewHelper(cfg)
+	if err != nil {
+		return err
+	}
+
+	client := NewClient(helper)
+	phase, err := client.PhaseByID(c.Options.PhaseID)
+	if err != nil {
+		return err
+	}
+
+	details, err := phase.Details()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(c.Writer, details)
+	return err
+}
+
+// ValidateFlags options for phase validate command
+type ValidateFlags struct {
+	// PhaseID is a phase to validate, all phases from the plan are validated if name is not set
+	PhaseID ifc.ID
+}
+
+// ValidateCommand phase validate command
+type ValidateCommand struct {
+	Options ValidateFlags
+	Factory config.Factory
+	Writer  io.Writer
+}
+
+// RunE validates a single phase or all phases defined in the plan
+func (c *ValidateCommand) RunE() error {
+	cfg, err := c.Factory()
+	if err != nil {
+		return err
+	}
+
+	helper, err := NewHelper(cfg)
+	if err != nil {
+		return err
+	}
+
+	ids := []ifc.ID{c.Options.PhaseID}
+	if c.Options.PhaseID.Name == "" {
+		plan, planErr := helper.Plan()
+		if planErr != nil {
+			return planErr
+		}
+		ids = []ifc.ID{}
+		for _, group := range plan.PhaseGroups {
+			for _, step := range group.Phases {
+				ids = append(ids, ifc.ID{Name: step.Name})
+			}
+		}
+	}
+
+	client := NewClient(helper)
+	invalid := []string{}
+	for _, id := range ids {
+		if err = validatePhase(client, id); err != nil {
+			fmt.Fprintf(c.Writer, "phase %s is invalid: %v\n", id.Name, err)
+			invalid = append(invalid, id.Name)
+			continue
+		}
+		fmt.Fprintf(c.Writer, "phase %s is valid\n", id.Name)
+	}
+	if len(invalid) > 0 {
+		return ErrInvalidPhases{Phases: invalid}
+	}
+	return nil
+}
+
+func validatePhase(client ifc.Client, id ifc.ID) error {
+	p, err := client.PhaseByID(id)
+	if err != nil {
+		return err
+	}
+	return p.Validate()
+}
+
+// HistoryFlags holds filters for phase history command
+type HistoryFlags struct {
+	PhaseName   string
+	ClusterName string
+	Result      string
+	// Since filters out runs started earlier than the given duration ago
+	Since time.Duration
+	// Limit is a maximum number of the latest runs to show
+	Limit int
+}
+
+// HistoryCommand phase history command
+type HistoryCommand struct {
+	Options HistoryFlags
+	Factory config.Factory
+	Writer  io.Writer
+}
+
+// RunE prints phase runs saved in the history
+func (c *HistoryCommand) RunE() error {
+	result := history.Result(c.Options.Result)
+	if result != "" && result != history.ResultSucceeded && result != history.ResultFailed {
+		return ErrInvalidHistoryResult{Result: c.Options.Result}
+	}
+
+	cfg, err := c.Factory()
+	if err != nil {
+		return err
+	}
+
+	helper, err := NewHelper(cfg)
+	if err != nil {
+		return err
+	}
+
+	wd, err := helper.WorkDir()
+	if err != nil {
+		return err
+	}
+
+	filter := history.Filter{
+		PhaseName:   c.Options.PhaseName,
+		ClusterName: c.Options.ClusterName,
+		Result:      result,
+		Limit:       c.Options.Limit,
+	}
+	if c.Options.Since > 0 {
+		filter.Since = time.Now().Add(-c.Options.Since)
+	}
+
+	records, err := history.NewStore(wd).List(filter)
+	if err != nil {
+		return err
+	}
+	return history.PrintRecords(records, c.Writer)
+}
+
+// EventsReplayFlags options for phase events replay command
+type EventsReplayFlags struct {
+	// File is a path to the events file recorded by phase or plan run
+	File string
+	// Output is a format of the printed events, one of text or json
+	Output string
+}
+
+// EventsReplayCommand phase events replay command
+type EventsReplayCommand struct {
+	Options EventsReplayFlags
+}
+
+// RunE passes events recorded in the events file to the event processor
+func (c *EventsReplayCommand) RunE() error {
+	processor, err := eventProcessor(c.Options.Output)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(c.Options.File)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return events.Replay(f, processor())
+}
+
 // RenderFlags holds filters for selector
 type RenderFlags struct {
 	// Label filters documents by label string
@@ -15,7 +15,10 @@
 package phase_test
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,6 +26,8 @@ import (
 
 	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/phase"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/testutil"
 )
 
 const (
@@ -75,6 +80,11 @@ func TestRunCommand(t *testing.T) {
 			},
 			errContains: testNoBundlePath,
 		},
+		{
+			name:        "Error invalid output format",
+			runFlags:    phase.RunFlags{Output: "xml"},
+			errContains: phase.ErrInvalidEventFormat{RequestedFormat: "xml"}.Error(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -157,3 +167,366 @@ func TestPlanCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestPlanRunCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		errContains string
+		factory     config.Factory
+	}{
+		{
+			name: "Error config factory",
+			factory: func() (*config.Config, error) {
+				return nil, fmt.Errorf(testFactoryErr)
+			},
+			errContains: testFactoryErr,
+		},
+		{
+			name: "Error new helper",
+			factory: func() (*config.Config, error) {
+				return &config.Config{
+					CurrentContext: "does not exist",
+					Contexts:       make(map[string]*config.Context),
+				}, nil
+			},
+			errContains: testNewHelperErr,
+		},
+		{
+			name: "Error plan",
+			factory: func() (*config.Config, error) {
+				conf := config.NewConfig()
+				conf.Manifests = map[string]*config.Manifest{
+					"manifest": {
+						MetadataPath: "broken_metadata.yaml",
+						TargetPath:   "testdata",
+					},
+				}
+				conf.CurrentContext = "context"
+				conf.Contexts = map[string]*config.Context{
+					"context": {
+						Manifest: "manifest",
+					},
+				}
+				return conf, nil
+			},
+			errContains: testNoBundlePath,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			command := phase.PlanRunCommand{
+				Factory: tt.factory,
+				Writer:  ioutil.Discard,
+			}
+			err := command.RunE()
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHistoryCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		errContains string
+		options     phase.HistoryFlags
+		factory     config.Factory
+	}{
+		{
+			name:        "Error invalid result filter",
+			options:     phase.HistoryFlags{Result: "Unknown"},
+			errContains: phase.ErrInvalidHistoryResult{Result: "Unknown"}.Error(),
+		},
+		{
+			name: "Error config factory",
+			factory: func() (*config.Config, error) {
+				return nil, fmt.Errorf(testFactoryErr)
+			},
+			errContains: testFactoryErr,
+		},
+		{
+			name: "Error new helper",
+			factory: func() (*config.Config, error) {
+				return &config.Config{
+					CurrentContext: "does not exist",
+					Contexts:       make(map[string]*config.Context),
+				}, nil
+			},
+			errContains: testNewHelperErr,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			command := phase.HistoryCommand{
+				Options: tt.options,
+				Factory: tt.factory,
+				Writer:  ioutil.Discard,
+			}
+			err := command.RunE()
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEventsReplayCommand(t *testing.T) {
+	dir, cleanup := testutil.TempDir(t, "events")
+	defer cleanup(t)
+	eventsFile := filepath.Join(dir, "events.json")
+	content := `{"type":"Clusterctl","timestamp":"2021-01-01T00:00:00Z","phase":"clusterctl-init",` +
+		`"clusterctl":{"Operation":0,"Message":"starting clusterctl init"}}
+{"type":"Error","timestamp":"2021-01-01T00:00:01Z","phase":"clusterctl-init","error":"init failed"}
+`
+	require.NoError(t, ioutil.WriteFile(eventsFile, []byte(content), 0600))
+
+	tests := []struct {
+		name        string
+		errContains string
+		flags       phase.EventsReplayFlags
+	}{
+		{
+			name:        "Error invalid output format",
+			flags:       phase.EventsReplayFlags{File: eventsFile, Output: "xml"},
+			errContains: phase.ErrInvalidEventFormat{RequestedFormat: "xml"}.Error(),
+		},
+		{
+			name:        "Error events file doesn't exist",
+			flags:       phase.EventsReplayFlags{File: filepath.Join(dir, "does-not-exist.json")},
+			errContains: testNoBundlePath,
+		},
+		{
+			name:        "Error recorded events contain errors",
+			flags:       phase.EventsReplayFlags{File: eventsFile},
+			errContains: "init failed",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			command := phase.EventsReplayCommand{Options: tt.flags}
+			err := command.RunE()
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateCommand(t *testing.T) {
+	tests := []struct {
+		name           string
+		errContains    string
+		options        phase.ValidateFlags
+		factory        config.Factory
+		expectedOutput []string
+	}{
+		{
+			name: "Error config factory",
+			factory: func() (*config.Config, error) {
+				return nil, fmt.Errorf(testFactoryErr)
+			},
+			errContains: testFactoryErr,
+		},
+		{
+			name: "Error new helper",
+			factory: func() (*config.Config, error) {
+				return &config.Config{
+					CurrentContext: "does not exist",
+					Contexts:       make(map[string]*config.Context),
+				}, nil
+			},
+			errContains: testNewHelperErr,
+		},
+		{
+			name: "Error plan",
+			factory: func() (*config.Config, error) {
+				conf := config.NewConfig()
+				conf.Manifests = map[string]*config.Manifest{
+					"manifest": {
+						MetadataPath: "broken_metadata.yaml",
+						TargetPath:   "testdata",
+					},
+				}
+				conf.CurrentContext = "context"
+				conf.Contexts = map[string]*config.Context{
+					"context": {
+						Manifest: "manifest",
+					},
+				}
+				return conf, nil
+			},
+			errContains: testNoBundlePath,
+		},
+		{
+			name: "Error single phase is invalid",
+			factory: func() (*config.Config, error) {
+				return planSiteConfig(t), nil
+			},
+			options:        phase.ValidateFlags{PhaseID: ifc.ID{Name: "broken_phase"}},
+			errContains:    phase.ErrInvalidPhases{Phases: []string{"broken_phase"}}.Error(),
+			expectedOutput: []string{"phase broken_phase is invalid"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			command := phase.ValidateCommand{
+				Options: tt.options,
+				Factory: tt.factory,
+				Writer:  buf,
+			}
+			err := command.RunE()
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+			for _, expected := range tt.expectedOutput {
+				assert.Contains(t, buf.String(), expected)
+			}
+		})
+	}
+}
+
+func TestDescribeCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		errContains string
+		options     phase.DescribeFlags
+		factory     config.Factory
+	}{
+		{
+			name: "Error config factory",
+			factory: func() (*config.Config, error) {
+				return nil, fmt.Errorf(testFactoryErr)
+			},
+			errContains: testFactoryErr,
+		},
+		{
+			name: "Error new helper",
+			factory: func() (*config.Config, error) {
+				return &config.Config{
+					CurrentContext: "does not exist",
+					Contexts:       make(map[string]*config.Context),
+				}, nil
+			},
+			errContains: testNewHelperErr,
+		},
+		{
+			name: "Error phase details",
+			factory: func() (*config.Config, error) {
+				return planSiteConfig(t), nil
+			},
+			options:     phase.DescribeFlags{PhaseID: ifc.ID{Name: "broken_phase"}},
+			errContains: "found no documents",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			command := phase.DescribeCommand{
+				Options: tt.options,
+				Factory: tt.factory,
+				Writer:  ioutil.Discard,
+			}
+			err := command.RunE()
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestListCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		errContains string
+		options     phase.ListFlags
+		factory     config.Factory
+		contains    []string
+		notContains []string
+	}{
+		{
+			name: "Error config factory",
+			factory: func() (*config.Config, error) {
+				return nil, fmt.Errorf(testFactoryErr)
+			},
+			errContains: testFactoryErr,
+		},
+		{
+			name: "Error new helper",
+			factory: func() (*config.Config, error) {
+				return &config.Config{
+					CurrentContext: "does not exist",
+					Contexts:       make(map[string]*config.Context),
+				}, nil
+			},
+			errContains: testNewHelperErr,
+		},
+		{
+			name: "Error invalid output format",
+			factory: func() (*config.Config, error) {
+				return planSiteConfig(t), nil
+			},
+			options:     phase.ListFlags{Output: "xml"},
+			errContains: phase.ErrInvalidOutputFormat{RequestedFormat: "xml"}.Error(),
+		},
+		{
+			name: "Success filter by label",
+			factory: func() (*config.Config, error) {
+				return planSiteConfig(t), nil
+			},
+			options:     phase.ListFlags{Output: phase.TableOutputFormat, Label: "airshipit.org/stage=target"},
+			contains:    []string{"phase_three"},
+			notContains: []string{"phase_one", "broken_phase"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			command := phase.ListCommand{
+				Options: tt.options,
+				Factory: tt.factory,
+				Writer:  buf,
+			}
+			err := command.RunE()
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+			for _, expected := range tt.contains {
+				assert.Contains(t, buf.String(), expected)
+			}
+			for _, unexpected := range tt.notContains {
+				assert.NotContains(t, buf.String(), unexpected)
+			}
+		})
+	}
+}
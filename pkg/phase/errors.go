@@ -15,9 +15,13 @@
 package phase
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"opendev.org/airship/airshipctl/pkg/phase/history"
 )
 
 // ErrExecutorNotFound is returned if phase executor was not found in executor
@@ -49,3 +53,193 @@ type ErrDocumentEntrypointNotDefined struct {
 func (e ErrDocumentEntrypointNotDefined) Error() string {
 	return fmt.Sprintf("documentEntryPoint not defined for the phase %s/%s", e.PhaseName, e.PhaseNamespace)
 }
+
+// ErrPhaseNotInPlan returned when requested phase is not defined in the phase plan
+type ErrPhaseNotInPlan struct {
+	PhaseName string
+	PlanName  string
+}
+
+func (e ErrPhaseNotInPlan) Error() string {
+	return fmt.Sprintf("phase %s is not defined in phase plan %s", e.PhaseName, e.PlanName)
+}
+
+// ErrInvalidPlanRange returned when phase to start plan execution from is defined
+// after the phase to stop plan execution at
+type ErrInvalidPlanRange struct {
+	StartAt   string
+	StopAfter string
+}
+
+func (e ErrInvalidPlanRange) Error() string {
+	return fmt.Sprintf("phase %s to start at is defined after phase %s to stop after", e.StartAt, e.StopAfter)
+}
+
+// ErrEmptyPlan returned when phase plan has no phases defined
+type ErrEmptyPlan struct {
+	PlanName string
+}
+
+func (e ErrEmptyPlan) Error() string {
+	return fmt.Sprintf("phase plan %s has no phases defined", e.PlanName)
+}
+
+// ErrPhaseDuplicatedInPlan returned when the same phase is defined in the phase plan more than once
+type ErrPhaseDuplicatedInPlan struct {
+	PhaseName string
+	PlanName  string
+}
+
+func (e ErrPhaseDuplicatedInPlan) Error() string {
+	return fmt.Sprintf("phase %s is defined more than once in phase plan %s", e.PhaseName, e.PlanName)
+}
+
+// ErrUnknownDependency returned when phase depends on a phase that is not defined in the phase plan
+type ErrUnknownDependency struct {
+	PhaseName  string
+	Dependency string
+	PlanName   string
+}
+
+func (e ErrUnknownDependency) Error() string {
+	return fmt.Sprintf("phase %s depends on phase %s which is not defined in phase plan %s",
+		e.PhaseName, e.Dependency, e.PlanName)
+}
+
+// ErrDependencyNotInRange returned when phase selected for execution depends on a phase
+// defined after the phase to stop plan execution at, so the phase could never be started
+type ErrDependencyNotInRange struct {
+	PhaseName  string
+	Dependency string
+	StopAfter  string
+}
+
+func (e ErrDependencyNotInRange) Error() string {
+	return fmt.Sprintf("phase %s depends on phase %s which is defined after phase %s to stop after",
+		e.PhaseName, e.Dependency, e.StopAfter)
+}
+
+// ErrDependencyCycle returned when phase dependencies defined in the phase plan form a cycle
+type ErrDependencyCycle struct {
+	Phases   []string
+	PlanName string
+}
+
+func (e ErrDependencyCycle) Error() string {
+	return fmt.Sprintf("phase plan %s has a dependency cycle: %s", e.PlanName, strings.Join(e.Phases, " -> "))
+}
+
+// ErrInvalidHistoryResult returned when phase history is filtered by unknown result
+type ErrInvalidHistoryResult struct {
+	Result string
+}
+
+func (e ErrInvalidHistoryResult) Error() string {
+	return fmt.Sprintf("invalid phase run result %s, must be one of: %s, %s",
+		e.Result, history.ResultSucceeded, history.ResultFailed)
+}
+
+// ErrInvalidOutputFormat returned when unsupported output format is requested
+type ErrInvalidOutputFormat struct {
+	RequestedFormat string
+}
+
+func (e ErrInvalidOutputFormat) Error() string {
+	return fmt.Sprintf("invalid output format %s, must be one of: %s, %s, %s",
+		e.RequestedFormat, TableOutputFormat, YAMLOutputFormat, JSONOutputFormat)
+}
+
+// ErrInvalidEventFormat returned when unsupported format of phase run events is requested
+type ErrInvalidEventFormat struct {
+	RequestedFormat string
+}
+
+func (e ErrInvalidEventFormat) Error() string {
+	return fmt.Sprintf("invalid events output format %s, must be one of: %s, %s",
+		e.RequestedFormat, TextEventFormat, JSONEventFormat)
+}
+
+// ErrInvalidPhases returned when phase validation has failed
+type ErrInvalidPhases struct {
+	Phases []string
+}
+
+func (e ErrInvalidPhases) Error() string {
+	return fmt.Sprintf("validation has failed for phases: %s", strings.Join(e.Phases, ", "))
+}
+
+// ErrPhaseCancelled returned when phase run was cancelled or its timeout has expired
+type ErrPhaseCancelled struct {
+	PhaseName string
+	// Err is a reason of cancellation, either context.Canceled or context.DeadlineExceeded
+	Err error
+}
+
+func (e ErrPhaseCancelled) Error() string {
+	if e.Err == context.DeadlineExceeded {
+		return fmt.Sprintf("phase %s is cancelled: timeout has expired", e.PhaseName)
+	}
+	return fmt.Sprintf("phase %s is cancelled", e.PhaseName)
+}
+
+// ErrPhaseHookFailed returned when pre-run or post-run hook of the phase has failed
+type ErrPhaseHookFailed struct {
+	PhaseName string
+	Stage     string
+	Hook      string
+	Err       error
+}
+
+func (e ErrPhaseHookFailed) Error() string {
+	return fmt.Sprintf("%s hook %s of phase %s has failed: %v", e.Stage, e.Hook, e.PhaseName, e.Err)
+}
+
+// ErrInvalidPhaseHook returned when pre-run or post-run hook of the phase is misconfigured
+type ErrInvalidPhaseHook struct {
+	PhaseName string
+	Stage     string
+	Hook      string
+	Reason    string
+}
+
+func (e ErrInvalidPhaseHook) Error() string {
+	return fmt.Sprintf("%s hook %s of phase %s is invalid: %s", e.Stage, e.Hook, e.PhaseName, e.Reason)
+}
+
+// ErrEmptyHookCommand returned when the hook of the phase has neither a phase nor a command to run
+type ErrEmptyHookCommand struct {
+	PhaseName string
+}
+
+func (e ErrEmptyHookCommand) Error() string {
+	return fmt.Sprintf("hook of phase %s has neither phase nor command to run", e.PhaseName)
+}
+
+// ErrPhaseHookCycle returned when phases are executed as hooks of each other
+type ErrPhaseHookCycle struct {
+	Phases []string
+}
+
+func (e ErrPhaseHookCycle) Error() string {
+	return fmt.Sprintf("phases form a hook cycle: %s", strings.Join(e.Phases, " -> "))
+}
+
+// ErrDiffNotSupported returned when diff is requested for a phase which executor can't show changes
+type ErrDiffNotSupported struct {
+	PhaseName    string
+	ExecutorKind string
+}
+
+func (e ErrDiffNotSupported) Error() string {
+	return fmt.Sprintf("phase %s can't be diffed, executor %s doesn't support diff", e.PhaseName, e.ExecutorKind)
+}
+
+// ErrInvalidRetryPolicy returned when retry policy of the phase is misconfigured
+type ErrInvalidRetryPolicy struct {
+	PhaseName string
+	Reason    string
+}
+
+func (e ErrInvalidRetryPolicy) Error() string {
+	return fmt.Sprintf("retry policy of phase %s is invalid: %s", e.PhaseName, e.Reason)
+}
@@ -15,6 +15,7 @@
 package phase
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -27,6 +28,7 @@ import (
 	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
 	"opendev.org/airship/airshipctl/pkg/util"
+	"opendev.org/airship/airshipctl/pkg/util/yaml"
 )
 
 // Helper provides functions built around phase bundle to filter and build documents
@@ -36,11 +38,12 @@ type Helper struct {
 	targetPath    string
 
 	metadata *config.Metadata
+	config   *config.Config
 }
 
 // NewHelper constructs metadata interface based on config
 func NewHelper(cfg *config.Config) (ifc.Helper, error) {
-	helper := &Helper{}
+	helper := &Helper{config: cfg}
 
 	var err error
 	helper.targetPath, err = cfg.CurrentContextTargetPath()
@@ -103,11 +106,15 @@ func (helper *Helper) Plan() (*v1alpha1.PhasePlan, error) {
 	if err := doc.ToAPIObject(plan, v1alpha1.Scheme); err != nil {
 		return nil, err
 	}
+
+	if err := ValidatePlan(plan); err != nil {
+		return nil, err
+	}
 	return plan, nil
 }
 
-// ListPhases returns all phases associated with manifest
-func (helper *Helper) ListPhases() ([]*v1alpha1.Phase, error) {
+// ListPhases returns phases associated with manifest which match the options
+func (helper *Helper) ListPhases(o ifc.ListPhaseOptions) ([]*v1alpha1.Phase, error) {
 	bundle, err := document.NewBundleByPath(helper.phaseRoot)
 	if err != nil {
 		return nil, err
@@ -119,7 +126,7 @@ func (helper *Helper) ListPhases() ([]*v1alpha1.Phase, error) {
 		return nil, err
 	}
 
-	docs, err := bundle.Select(selector)
+	docs, err := bundle.Select(selector.ByLabel(o.Label))
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +137,10 @@ func (helper *Helper) ListPhases() ([]*v1alpha1.Phase, error) {
 		if err = doc.ToAPIObject(p, v1alpha1.Scheme); err != nil {
 			return nil, err
 		}
-		phases = append(phases, phase)
+		if o.ClusterName != "" && p.ClusterName != o.ClusterName {
+			continue
+		}
+		phases = append(phases, p)
 	}
 	return phases, nil
 }
@@ -159,13 +169,17 @@ func (helper *Helper) ClusterMapAPIobj() (*v1alpha1.ClusterMap, error) {
 	return cMap, nil
 }
 
-// ClusterMap associated with the the manifest
+// ClusterMap associated with the the manifest, cluster map is validated before it is returned
 func (helper *Helper) ClusterMap() (clustermap.ClusterMap, error) {
-	cMap, err := helper.ClusterMapAPIobj()
+	apiMap, err := helper.ClusterMapAPIobj()
 	if err != nil {
 		return nil, err
 	}
-	return clustermap.NewClusterMap(cMap), nil
+	cMap := clustermap.NewClusterMap(apiMap)
+	if err = cMap.Validate(); err != nil {
+		return nil, err
+	}
+	return cMap, nil
 }
 
 // ExecutorDoc returns executor document associated with phase
@@ -195,6 +209,11 @@ func (helper *Helper) TargetPath() string {
 	return helper.targetPath
 }
 
+// AirshipConfig returns airship config the helper is built from
+func (helper *Helper) AirshipConfig() *config.Config {
+	return helper.config
+}
+
 // PhaseRoot returns path to document root with phase documents
 func (helper *Helper) PhaseRoot() string {
 	return helper.phaseRoot
@@ -229,3 +248,53 @@ func PrintPlan(plan *v1alpha1.PhasePlan, w io.Writer) error {
 	}
 	return nil
 }
+
+// Supported output formats of phase list
+const (
+	TableOutputFormat = "table"
+	YAMLOutputFormat  = "yaml"
+	JSONOutputFormat  = "json"
+)
+
+// PrintPhaseList prints phases in the requested format
+func PrintPhaseList(phases []*v1alpha1.Phase, format string, w io.Writer) error {
+	switch format {
+	case TableOutputFormat:
+		return printPhaseTable(phases, w)
+	case YAMLOutputFormat:
+		for _, p := range phases {
+			if err := yaml.WriteOut(w, p); err != nil {
+				return err
+			}
+		}
+		return nil
+	case JSONOutputFormat:
+		out, err := json.MarshalIndent(phases, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	default:
+		return ErrInvalidOutputFormat{RequestedFormat: format}
+	}
+}
+
+func printPhaseTable(phases []*v1alpha1.Phase, w io.Writer) error {
+	tw := util.NewTabWriter(w)
+	defer tw.Flush()
+	fmt.Fprintf(tw, "NAMESPACE\tNAME\tCLUSTER NAME\tEXECUTOR\tDOC ENTRYPOINT\n")
+	for _, p := range phases {
+		executor := ""
+		if ref := p.Config.ExecutorRef; ref != nil {
+			executor = fmt.Sprintf("%s/%s", ref.Kind, ref.Name)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			p.Namespace,
+			p.Name,
+			p.ClusterName,
+			executor,
+			p.Config.DocumentEntryPoint)
+	}
+	return nil
+}
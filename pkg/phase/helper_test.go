@@ -58,6 +58,7 @@ func TestHelperPhase(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "capi_init",
 				},
+				Description: "Initialize cluster-api providers",
 				Config: airshipv1.PhaseConfig{
 					ExecutorRef: &corev1.ObjectReference{
 						Kind:       "Clusterctl",
@@ -190,12 +191,28 @@ func TestHelperListPhases(t *testing.T) {
 		name        string
 		errContains string
 		phaseLen    int
+		phaseNames  []string
+		options     ifc.ListPhaseOptions
 		config      func(t *testing.T) *config.Config
 	}{
 		{
-			name:     "Success phase list",
+			name:       "Success phase list",
+			phaseLen:   2,
+			phaseNames: []string{"some_phase", "capi_init"},
+			config:     testConfig,
+		},
+		{
+			name:     "Success filter by label",
 			phaseLen: 2,
-			config:   testConfig,
+			options:  ifc.ListPhaseOptions{Label: "airshipit.org/stage=ephemeral"},
+			config:   planSiteConfig,
+		},
+		{
+			name:       "Success filter by cluster name",
+			phaseLen:   1,
+			phaseNames: []string{"phase_three"},
+			options:    ifc.ListPhaseOptions{ClusterName: "target"},
+			config:     planSiteConfig,
 		},
 		{
 			name: "Error bundle path doesn't exist",
@@ -224,13 +241,16 @@ func TestHelperListPhases(t *testing.T) {
 			require.NoError(t, err)
 			require.NotNil(t, helper)
 
-			actualList, actualErr := helper.ListPhases()
+			actualList, actualErr := helper.ListPhases(tt.options)
 			if tt.errContains != "" {
 				require.Error(t, actualErr)
 				assert.Contains(t, actualErr.Error(), tt.errContains)
 			} else {
 				require.NoError(t, actualErr)
 				assert.Len(t, actualList, tt.phaseLen)
+				for i, name := range tt.phaseNames {
+					assert.Equal(t, name, actualList[i].Name)
+				}
 			}
 		})
 	}
@@ -418,6 +438,14 @@ func TestHelperTargetPath(t *testing.T) {
 	assert.Equal(t, "testdata", helper.TargetPath())
 }
 
+func TestHelperAirshipConfig(t *testing.T) {
+	cfg := testConfig(t)
+	helper, err := phase.NewHelper(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, helper)
+	assert.Equal(t, cfg, helper.AirshipConfig())
+}
+
 func TestHelperPhaseRoot(t *testing.T) {
 	helper, err := phase.NewHelper(testConfig(t))
 	require.NoError(t, err)
@@ -456,3 +484,65 @@ manifests:
 	require.NoError(t, err)
 	return conf
 }
+
+func TestPrintPhaseList(t *testing.T) {
+	phases := []*airshipv1.Phase{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "capi_init",
+				ClusterName: "ephemeral-cluster",
+			},
+			Config: airshipv1.PhaseConfig{
+				ExecutorRef: &corev1.ObjectReference{
+					Kind: "Clusterctl",
+					Name: "clusterctl-v1",
+				},
+				DocumentEntryPoint: "valid_site/phases",
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		format      string
+		contains    []string
+		errContains string
+	}{
+		{
+			name:     "table",
+			format:   phase.TableOutputFormat,
+			contains: []string{"CLUSTER NAME", "capi_init", "ephemeral-cluster", "Clusterctl/clusterctl-v1"},
+		},
+		{
+			name:     "yaml",
+			format:   phase.YAMLOutputFormat,
+			contains: []string{"---\n", "name: capi_init", "documentEntryPoint: valid_site/phases"},
+		},
+		{
+			name:     "json",
+			format:   phase.JSONOutputFormat,
+			contains: []string{`"name": "capi_init"`, `"documentEntryPoint": "valid_site/phases"`},
+		},
+		{
+			name:        "invalid format",
+			format:      "xml",
+			errContains: phase.ErrInvalidOutputFormat{RequestedFormat: "xml"}.Error(),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			err := phase.PrintPhaseList(phases, tt.format, buf)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			for _, expected := range tt.contains {
+				assert.Contains(t, buf.String(), expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package history
+
+import (
+	"fmt"
+)
+
+// ErrMalformedRecord returned when history file contains a line which is not a valid record
+type ErrMalformedRecord struct {
+	Path string
+	Line int
+	Err  error
+}
+
+func (e ErrMalformedRecord) Error() string {
+	return fmt.Sprintf("malformed phase history record in file %s at line %d: %v", e.Path, e.Line, e.Err)
+}
@@ -0,0 +1,193 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+
+	"opendev.org/airship/airshipctl/pkg/util"
+)
+
+const (
+	// FileName is the name of the file in airship config directory which holds phase execution history
+	FileName = "phase-history.jsonl"
+	// maxRecordSize limits size of a single history record, errors of the phase run may be long
+	maxRecordSize = 16 * 1024 * 1024
+)
+
+// Result describes the outcome of the phase run
+type Result string
+
+const (
+	// ResultSucceeded phase run returned no errors
+	ResultSucceeded Result = "Succeeded"
+	// ResultFailed phase run returned an error
+	ResultFailed Result = "Failed"
+)
+
+// Record holds information about a single phase run
+type Record struct {
+	PhaseName      string    `json:"phaseName"`
+	PhaseNamespace string    `json:"phaseNamespace,omitempty"`
+	ClusterName    string    `json:"clusterName,omitempty"`
+	StartTime      time.Time `json:"startTime"`
+	EndTime        time.Time `json:"endTime"`
+	Result         Result    `json:"result"`
+	Errors         []string  `json:"errors,omitempty"`
+	Revision       string    `json:"revision,omitempty"`
+	DryRun         bool      `json:"dryRun,omitempty"`
+}
+
+// Filter is used to select records from the history, empty fields match any record
+type Filter struct {
+	PhaseName   string
+	ClusterName string
+	Result      Result
+	// Since selects records of the runs started after the given time
+	Since time.Time
+	// Limit is a maximum number of the latest records returned, all records are returned if not set
+	Limit int
+}
+
+// Match returns true if the record satisfies the filter
+func (f Filter) Match(r Record) bool {
+	return (f.PhaseName == "" || f.PhaseName == r.PhaseName) &&
+		(f.ClusterName == "" || f.ClusterName == r.ClusterName) &&
+		(f.Result == "" || f.Result == r.Result) &&
+		(f.Since.IsZero() || !r.StartTime.Before(f.Since))
+}
+
+// Recorder is an interface to save phase runs
+type Recorder interface {
+	Add(Record) error
+}
+
+// Store keeps phase run records in a file, one JSON object per line
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewStore returns history store located in the given directory
+func NewStore(dir string) *Store {
+	return &Store{path: filepath.Join(dir, FileName)}
+}
+
+// Add appends the record to the history file, the file and its directory are created if needed
+func (s *Store) Add(r Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(r)
+}
+
+// List returns records matching the filter in the order they were added
+func (s *Store) List(filter Filter) ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records := []Record{}
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxRecordSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		r := Record{}
+		if err = json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, ErrMalformedRecord{Path: s.path, Line: line, Err: err}
+		}
+		if filter.Match(r) {
+			records = append(records, r)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[len(records)-filter.Limit:]
+	}
+	return records, nil
+}
+
+// Revision returns hash of the commit checked out in git repository containing the path,
+// empty string is returned if the path is not a part of git repository
+func Revision(path string) string {
+	repo, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return ""
+	}
+	ref, err := repo.Head()
+	if err != nil {
+		return ""
+	}
+	return ref.Hash().String()
+}
+
+// PrintRecords prints history records as a table
+func PrintRecords(records []Record, w io.Writer) error {
+	tw := util.NewTabWriter(w)
+	defer tw.Flush()
+	fmt.Fprintf(tw, "PHASE\tCLUSTER\tSTARTED\tDURATION\tRESULT\tREVISION\tERROR\n")
+	for _, r := range records {
+		revision := r.Revision
+		if len(revision) > 8 {
+			revision = revision[:8]
+		}
+		result := string(r.Result)
+		if r.DryRun {
+			result += " (dry-run)"
+		}
+		// keep every record on a single line of the table
+		errMessage := strings.ReplaceAll(strings.Join(r.Errors, "; "), "\n", " ")
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.PhaseName,
+			r.ClusterName,
+			r.StartTime.Format(time.RFC3339),
+			r.EndTime.Sub(r.StartTime).Round(time.Second),
+			result,
+			revision,
+			errMessage)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package history_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/phase/history"
+	"opendev.org/airship/airshipctl/testutil"
+)
+
+func testRecords() []history.Record {
+	start := time.Date(2020, time.June, 1, 10, 0, 0, 0, time.UTC)
+	return []history.Record{
+		{
+			PhaseName:   "initinfra",
+			ClusterName: "ephemeral-cluster",
+			StartTime:   start,
+			EndTime:     start.Add(time.Minute),
+			Result:      history.ResultSucceeded,
+			Revision:    "0123456789abcdef",
+		},
+		{
+			PhaseName:   "controlplane",
+			ClusterName: "ephemeral-cluster",
+			StartTime:   start.Add(time.Hour),
+			EndTime:     start.Add(2 * time.Hour),
+			Result:      history.ResultFailed,
+			Errors:      []string{"timeout"},
+		},
+		{
+			PhaseName:   "initinfra",
+			ClusterName: "target-cluster",
+			StartTime:   start.Add(3 * time.Hour),
+			EndTime:     start.Add(4 * time.Hour),
+			Result:      history.ResultSucceeded,
+			DryRun:      true,
+		},
+	}
+}
+
+func TestStore(t *testing.T) {
+	tempDir, cleanup := testutil.TempDir(t, "airship-history")
+	defer cleanup(t)
+
+	// store must create missing directory
+	store := history.NewStore(filepath.Join(tempDir, ".airship"))
+	records, err := store.List(history.Filter{})
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	for _, r := range testRecords() {
+		require.NoError(t, store.Add(r))
+	}
+
+	tests := []struct {
+		name     string
+		filter   history.Filter
+		expected []string
+	}{
+		{
+			name:     "all records",
+			expected: []string{"initinfra", "controlplane", "initinfra"},
+		},
+		{
+			name:     "by phase name",
+			filter:   history.Filter{PhaseName: "initinfra"},
+			expected: []string{"initinfra", "initinfra"},
+		},
+		{
+			name:     "by cluster and result",
+			filter:   history.Filter{ClusterName: "ephemeral-cluster", Result: history.ResultFailed},
+			expected: []string{"controlplane"},
+		},
+		{
+			name:     "since",
+			filter:   history.Filter{Since: time.Date(2020, time.June, 1, 11, 0, 0, 0, time.UTC)},
+			expected: []string{"controlplane", "initinfra"},
+		},
+		{
+			name:     "limit returns latest records",
+			filter:   history.Filter{Limit: 1},
+			expected: []string{"initinfra"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := store.List(tt.filter)
+			require.NoError(t, err)
+			actual := []string{}
+			for _, r := range records {
+				actual = append(actual, r.PhaseName)
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestStoreMalformedRecord(t *testing.T) {
+	tempDir, cleanup := testutil.TempDir(t, "airship-history")
+	defer cleanup(t)
+
+	err := ioutil.WriteFile(filepath.Join(tempDir, history.FileName), []byte("not a record\n"), 0600)
+	require.NoError(t, err)
+
+	_, err = history.NewStore(tempDir).List(history.Filter{})
+	require.Error(t, err)
+	assert.IsType(t, history.ErrMalformedRecord{}, err)
+}
+
+func TestStoreLongRecord(t *testing.T) {
+	tempDir, cleanup := testutil.TempDir(t, "airship-history")
+	defer cleanup(t)
+
+	store := history.NewStore(tempDir)
+	record := testRecords()[1]
+	record.Errors = []string{strings.Repeat("e", 1024*1024)}
+	require.NoError(t, store.Add(record))
+	records, err := store.List(history.Filter{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, record.Errors, records[0].Errors)
+}
+
+func TestRevision(t *testing.T) {
+	tempDir, cleanup := testutil.TempDir(t, "airship-history")
+	defer cleanup(t)
+
+	assert.Equal(t, "", history.Revision(tempDir))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	// the path is not checked in tests if source code is not a git checkout
+	if rev := history.Revision(wd); rev != "" {
+		assert.Len(t, rev, 40)
+	}
+}
+
+func TestPrintRecords(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, history.PrintRecords(testRecords(), buf))
+	out := buf.String()
+	assert.Contains(t, out, "PHASE")
+	assert.Contains(t, out, "01234567")
+	assert.NotContains(t, out, "0123456789abcdef")
+	assert.Contains(t, out, "Succeeded (dry-run)")
+	assert.Contains(t, out, "1h0m0s")
+	assert.Contains(t, out, "ERROR")
+	assert.Contains(t, out, "timeout")
+}
@@ -0,0 +1,229 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const (
+	// hookStagePreRun is a stage of hooks executed before the phase executor
+	hookStagePreRun = "preRun"
+	// hookStagePostRun is a stage of hooks executed after the phase executor
+	hookStagePostRun = "postRun"
+)
+
+// runWithHooks runs pre-run hooks, the executor with its retries and post-run hooks of the phase and sends
+// their events to the channel. Execution is stopped by the first failure, post-run hooks
+// are executed only if the executor has succeeded. Channel is closed when run is finished
+func (p *phase) runWithHooks(ctx context.Context, executor ifc.Executor, ch chan<- events.Event, ro ifc.RunOptions) {
+	defer close(ch)
+
+	if !p.runHooks(ctx, ch, ro, hookStagePreRun, p.apiObj.Config.PreRun) {
+		return
+	}
+
+	if !p.runExecutor(ctx, executor, ch, ro) {
+		return
+	}
+
+	p.runHooks(ctx, ch, ro, hookStagePostRun, p.apiObj.Config.PostRun)
+}
+
+// runHooks executes hooks one by one, false is returned if phase execution must be stopped
+func (p *phase) runHooks(
+	ctx context.Context,
+	ch chan<- events.Event,
+	ro ifc.RunOptions,
+	stage string,
+	hooks []v1alpha1.PhaseHook) bool {
+	for _, hook := range hooks {
+		name := hookName(hook)
+		ch <- hookEvent(events.PhaseHookStart, stage, name, fmt.Sprintf("running %s hook %s", stage, name))
+
+		err := p.runHook(ctx, ch, ro, hook)
+		if err == nil {
+			ch <- hookEvent(events.PhaseHookEnd, stage, name, fmt.Sprintf("%s hook %s is finished", stage, name))
+			continue
+		}
+
+		err = ErrPhaseHookFailed{PhaseName: p.apiObj.Name, Stage: stage, Hook: name, Err: err}
+		// cancelled run must be stopped regardless of the failure policy
+		if hook.FailurePolicy == v1alpha1.PhaseHookFailurePolicyWarn && ctx.Err() == nil {
+			ch <- hookEvent(events.PhaseHookWarning, stage, name, err.Error())
+			continue
+		}
+		ch <- events.Event{
+			Type:       events.ErrorType,
+			ErrorEvent: events.ErrorEvent{Error: err},
+		}
+		return false
+	}
+	return true
+}
+
+func (p *phase) runHook(ctx context.Context, ch chan<- events.Event, ro ifc.RunOptions, hook v1alpha1.PhaseHook) error {
+	if hook.Phase != "" {
+		return p.runHookPhase(ctx, ch, ro, hook.Phase)
+	}
+	return p.runHookCommand(ctx, ro, hook.Command)
+}
+
+// runHookPhase runs another phase as a hook, its events are sent to the channel of this phase
+// except for errors which are returned, so that they are handled according to the failure policy
+func (p *phase) runHookPhase(ctx context.Context, ch chan<- events.Event, ro ifc.RunOptions, name string) error {
+	hookOf := append(append([]string{}, p.hookOf...), p.apiObj.Name)
+	for _, parent := range hookOf {
+		if parent == name {
+			return ErrPhaseHookCycle{Phases: append(hookOf, name)}
+		}
+	}
+
+	phaseObj, err := p.helper.Phase(ifc.ID{Name: name, Namespace: p.apiObj.Namespace})
+	if err != nil {
+		return err
+	}
+	hookPhase := &phase{
+		apiObj:    phaseObj,
+		helper:    p.helper,
+		processor: &hookProcessor{out: ch},
+		registry:  p.registry,
+		recorder:  p.recorder,
+		hookOf:    hookOf,
+	}
+	// timeout of this phase run applies to its hooks as well
+	ro.Timeout = 0
+	return hookPhase.Run(ctx, ro)
+}
+
+// runHookCommand executes local command from the target path, command is killed if context is cancelled
+func (p *phase) runHookCommand(ctx context.Context, ro ifc.RunOptions, command []string) error {
+	if len(command) == 0 {
+		return ErrEmptyHookCommand{PhaseName: p.apiObj.Name}
+	}
+	if ro.DryRun {
+		log.Printf("command %v will be executed", command)
+		return nil
+	}
+	// command is defined in the phase document, which is trusted the same way as the rest of the manifests
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...) //nolint:gosec
+	cmd.Dir = p.helper.TargetPath()
+	cmd.Env = append(os.Environ(),
+		"AIRSHIP_PHASE_NAME="+p.apiObj.Name,
+		"AIRSHIP_PHASE_CLUSTER_NAME="+p.apiObj.ClusterName)
+	cmd.Stdout = log.Writer()
+	cmd.Stderr = log.Writer()
+	return cmd.Run()
+}
+
+// validateHooks makes sure that each hook either runs a known phase or a command
+func (p *phase) validateHooks() error {
+	stages := []struct {
+		name  string
+		hooks []v1alpha1.PhaseHook
+	}{
+		{name: hookStagePreRun, hooks: p.apiObj.Config.PreRun},
+		{name: hookStagePostRun, hooks: p.apiObj.Config.PostRun},
+	}
+	for _, stage := range stages {
+		for _, hook := range stage.hooks {
+			if err := p.validateHook(stage.name, hook); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *phase) validateHook(stage string, hook v1alpha1.PhaseHook) error {
+	invalid := func(reason string) error {
+		return ErrInvalidPhaseHook{PhaseName: p.apiObj.Name, Stage: stage, Hook: hookName(hook), Reason: reason}
+	}
+	switch {
+	case (hook.Phase == "") == (len(hook.Command) == 0):
+		return invalid("exactly one of phase and command must be set")
+	case hook.Phase == p.apiObj.Name:
+		return invalid("phase can't be a hook of itself")
+	case hook.FailurePolicy != "" && hook.FailurePolicy != v1alpha1.PhaseHookFailurePolicyFail &&
+		hook.FailurePolicy != v1alpha1.PhaseHookFailurePolicyWarn:
+		return invalid(fmt.Sprintf("unknown failure policy %s, must be one of: %s, %s", hook.FailurePolicy,
+			v1alpha1.PhaseHookFailurePolicyFail, v1alpha1.PhaseHookFailurePolicyWarn))
+	}
+	if hook.Phase != "" {
+		if _, err := p.helper.Phase(ifc.ID{Name: hook.Phase, Namespace: p.apiObj.Namespace}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hookName returns the name of the hook, or its phase or executable if name is not set
+func hookName(hook v1alpha1.PhaseHook) string {
+	switch {
+	case hook.Name != "":
+		return hook.Name
+	case hook.Phase != "":
+		return hook.Phase
+	case len(hook.Command) > 0:
+		return hook.Command[0]
+	}
+	return ""
+}
+
+func hookEvent(op events.PhaseHookOperation, stage, hook, message string) events.Event {
+	return events.Event{
+		Type: events.PhaseHookType,
+		PhaseHookEvent: events.PhaseHookEvent{
+			Operation: op,
+			Stage:     stage,
+			Hook:      hook,
+			Message:   message,
+		},
+	}
+}
+
+func isErrorEvent(e events.Event) bool {
+	return events.ErrorOf(e) != nil
+}
+
+// hookProcessor forwards events of the phase executed as a hook to the event channel of
+// the parent phase. Error events are not forwarded, they are returned by Process method
+type hookProcessor struct {
+	out chan<- events.Event
+}
+
+// Process is implementation of EventProcessor
+func (p *hookProcessor) Process(ch <-chan events.Event) error {
+	errs := []error{}
+	for e := range ch {
+		if err := events.ErrorOf(e); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		p.out <- e
+	}
+	if len(errs) > 0 {
+		return events.ErrEventReceived{Errors: errs}
+	}
+	return nil
+}
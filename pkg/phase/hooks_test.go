@@ -0,0 +1,229 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/phase"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+// recordingProcessor saves received events and fails if error events are received
+type recordingProcessor struct {
+	events []events.Event
+}
+
+func (p *recordingProcessor) Process(ch <-chan events.Event) error {
+	errs := []error{}
+	for e := range ch {
+		p.events = append(p.events, e)
+		if e.Type == events.ErrorType {
+			errs = append(errs, e.ErrorEvent.Error)
+		}
+	}
+	if len(errs) > 0 {
+		return events.ErrEventReceived{Errors: errs}
+	}
+	return nil
+}
+
+// hookOperations returns stage, hook name and operation of each hook event
+func hookOperations(evts []events.Event) []events.PhaseHookEvent {
+	ops := []events.PhaseHookEvent{}
+	for _, e := range evts {
+		if e.Type == events.PhaseHookType {
+			ops = append(ops, events.PhaseHookEvent{
+				Operation: e.PhaseHookEvent.Operation,
+				Stage:     e.PhaseHookEvent.Stage,
+				Hook:      e.PhaseHookEvent.Hook,
+			})
+		}
+	}
+	return ops
+}
+
+func TestPhaseRunHooks(t *testing.T) {
+	tests := []struct {
+		name        string
+		preRun      []v1alpha1.PhaseHook
+		postRun     []v1alpha1.PhaseHook
+		runOptions  ifc.RunOptions
+		expectedOps []events.PhaseHookEvent
+		errContains string
+	}{
+		{
+			name:    "Success hooks are executed around the phase",
+			preRun:  []v1alpha1.PhaseHook{{Name: "snapshot", Command: []string{"true"}}},
+			postRun: []v1alpha1.PhaseHook{{Command: []string{"true"}}},
+			expectedOps: []events.PhaseHookEvent{
+				{Operation: events.PhaseHookStart, Stage: "preRun", Hook: "snapshot"},
+				{Operation: events.PhaseHookEnd, Stage: "preRun", Hook: "snapshot"},
+				{Operation: events.PhaseHookStart, Stage: "postRun", Hook: "true"},
+				{Operation: events.PhaseHookEnd, Stage: "postRun", Hook: "true"},
+			},
+		},
+		{
+			name:       "Success commands are skipped in dry-run mode",
+			preRun:     []v1alpha1.PhaseHook{{Command: []string{"false"}}},
+			runOptions: ifc.RunOptions{DryRun: true},
+			expectedOps: []events.PhaseHookEvent{
+				{Operation: events.PhaseHookStart, Stage: "preRun", Hook: "false"},
+				{Operation: events.PhaseHookEnd, Stage: "preRun", Hook: "false"},
+			},
+		},
+		{
+			name:    "Error failed pre-run hook stops the phase",
+			preRun:  []v1alpha1.PhaseHook{{Command: []string{"false"}}},
+			postRun: []v1alpha1.PhaseHook{{Command: []string{"true"}}},
+			expectedOps: []events.PhaseHookEvent{
+				{Operation: events.PhaseHookStart, Stage: "preRun", Hook: "false"},
+			},
+			errContains: "preRun hook false of phase capi_init has failed",
+		},
+		{
+			name: "Success failed hook is reported with warn policy",
+			postRun: []v1alpha1.PhaseHook{
+				{Phase: "some_phase", FailurePolicy: v1alpha1.PhaseHookFailurePolicyWarn},
+				{Command: []string{"true"}},
+			},
+			expectedOps: []events.PhaseHookEvent{
+				{Operation: events.PhaseHookStart, Stage: "postRun", Hook: "some_phase"},
+				{Operation: events.PhaseHookWarning, Stage: "postRun", Hook: "some_phase"},
+				{Operation: events.PhaseHookStart, Stage: "postRun", Hook: "true"},
+				{Operation: events.PhaseHookEnd, Stage: "postRun", Hook: "true"},
+			},
+		},
+		{
+			name:    "Error phase hook fails",
+			postRun: []v1alpha1.PhaseHook{{Phase: "some_phase"}},
+			expectedOps: []events.PhaseHookEvent{
+				{Operation: events.PhaseHookStart, Stage: "postRun", Hook: "some_phase"},
+			},
+			errContains: "found no documents",
+		},
+		{
+			name:    "Error hook without phase and command",
+			preRun:  []v1alpha1.PhaseHook{{Name: "empty"}},
+			postRun: []v1alpha1.PhaseHook{{Command: []string{"true"}}},
+			expectedOps: []events.PhaseHookEvent{
+				{Operation: events.PhaseHookStart, Stage: "preRun", Hook: "empty"},
+			},
+			errContains: "hook of phase capi_init has neither phase nor command to run",
+		},
+		{
+			name:        "Error phase is a hook of itself",
+			preRun:      []v1alpha1.PhaseHook{{Phase: "capi_init"}},
+			errContains: "phases form a hook cycle: capi_init -> capi_init",
+			expectedOps: []events.PhaseHookEvent{
+				{Operation: events.PhaseHookStart, Stage: "preRun", Hook: "capi_init"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			helper, err := phase.NewHelper(testConfig(t))
+			require.NoError(t, err)
+			proc := &recordingProcessor{}
+			client := phase.NewClient(helper,
+				phase.InjectRegistry(fakeRegistry),
+				phase.InjectProcessor(func() events.EventProcessor { return proc }))
+
+			phaseObj, err := helper.Phase(ifc.ID{Name: "capi_init"})
+			require.NoError(t, err)
+			phaseObj.Config.PreRun = tt.preRun
+			phaseObj.Config.PostRun = tt.postRun
+			p, err := client.PhaseByAPIObj(phaseObj)
+			require.NoError(t, err)
+
+			err = p.Run(context.Background(), tt.runOptions)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedOps, hookOperations(proc.events))
+			for _, e := range proc.events {
+				assert.NotEmpty(t, e.PhaseName)
+				assert.False(t, e.Timestamp.IsZero())
+			}
+		})
+	}
+}
+
+func TestPhaseValidateHooks(t *testing.T) {
+	tests := []struct {
+		name        string
+		hook        v1alpha1.PhaseHook
+		errContains string
+	}{
+		{
+			name: "Success valid phase hook",
+			hook: v1alpha1.PhaseHook{Phase: "some_phase", FailurePolicy: v1alpha1.PhaseHookFailurePolicyWarn},
+		},
+		{
+			name:        "Error neither phase nor command is set",
+			hook:        v1alpha1.PhaseHook{Name: "empty"},
+			errContains: "exactly one of phase and command must be set",
+		},
+		{
+			name:        "Error both phase and command are set",
+			hook:        v1alpha1.PhaseHook{Phase: "some_phase", Command: []string{"true"}},
+			errContains: "exactly one of phase and command must be set",
+		},
+		{
+			name:        "Error unknown failure policy",
+			hook:        v1alpha1.PhaseHook{Command: []string{"true"}, FailurePolicy: "Ignore"},
+			errContains: "unknown failure policy Ignore",
+		},
+		{
+			name:        "Error unknown phase",
+			hook:        v1alpha1.PhaseHook{Phase: "does_not_exist"},
+			errContains: "found no documents",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			helper, err := phase.NewHelper(testConfig(t))
+			require.NoError(t, err)
+			client := phase.NewClient(helper, phase.InjectRegistry(fakeRegistry))
+
+			phaseObj, err := helper.Phase(ifc.ID{Name: "capi_init"})
+			require.NoError(t, err)
+			phaseObj.Config.PostRun = []v1alpha1.PhaseHook{tt.hook}
+			p, err := client.PhaseByAPIObj(phaseObj)
+			require.NoError(t, err)
+
+			err = p.Validate()
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@
 package ifc
 
 import (
+	"context"
 	"io"
 	"time"
 
@@ -25,18 +26,31 @@ import (
 	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
 )
 
-// Executor interface should be implemented by each runner
+// Executor interface should be implemented by each runner. Run must stop
+// and close the event channel when the context is cancelled
 type Executor interface {
-	Run(chan events.Event, RunOptions)
+	Run(context.Context, chan events.Event, RunOptions)
 	Render(io.Writer, RenderOptions) error
 	Validate() error
+	Details() (string, error)
+}
+
+// DiffExecutor is implemented by executors which are able to show the changes
+// a phase run would make without making them. Diff must close the event channel when done
+type DiffExecutor interface {
+	Executor
+	Diff(context.Context, chan events.Event)
 }
 
 // RunOptions holds options for run method
 type RunOptions struct {
 	Debug  bool
 	DryRun bool
+	// Diff shows changes the phase would make instead of running it,
+	// phase executor must implement DiffExecutor interface
+	Diff bool
 
+	// Timeout is a maximum duration of the phase run, phase is cancelled when it expires
 	Timeout time.Duration
 }
 
@@ -57,6 +71,8 @@ type ExecutorFactory func(config ExecutorConfig) (Executor, error)
 type ExecutorConfig struct {
 	PhaseName   string
 	ClusterName string
+	// KubeconfigContext is a context of the phase cluster in kubeconfig as defined by cluster map
+	KubeconfigContext string
 
 	ClusterMap       clustermap.ClusterMap
 	ExecutorDocument document.Document
@@ -17,6 +17,7 @@ package ifc
 import (
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
+	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/document"
 )
 
@@ -26,9 +27,18 @@ type Helper interface {
 	WorkDir() (string, error)
 	Phase(phaseID ID) (*v1alpha1.Phase, error)
 	Plan() (*v1alpha1.PhasePlan, error)
-	ListPhases() ([]*v1alpha1.Phase, error)
+	ListPhases(ListPhaseOptions) ([]*v1alpha1.Phase, error)
 	ClusterMapAPIobj() (*v1alpha1.ClusterMap, error)
 	ClusterMap() (clustermap.ClusterMap, error)
 	ExecutorDoc(phaseID ID) (document.Document, error)
 	PhaseRoot() string
+	AirshipConfig() *config.Config
+}
+
+// ListPhaseOptions allows to filter phases returned by helper
+type ListPhaseOptions struct {
+	// Label is a label selector phase documents must match
+	Label string
+	// ClusterName selects phases executed against the cluster
+	ClusterName string
 }
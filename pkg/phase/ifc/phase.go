@@ -15,6 +15,7 @@
 package ifc
 
 import (
+	"context"
 	"io"
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
@@ -24,7 +25,7 @@ import (
 // Phase provides a way to interact with a phase
 type Phase interface {
 	Validate() error
-	Run(RunOptions) error
+	Run(context.Context, RunOptions) error
 	DocumentRoot() (string, error)
 	Details() (string, error)
 	Executor() (Executor, error)
@@ -0,0 +1,334 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/pkg/util"
+)
+
+// PhaseStatus describes the outcome of a phase executed as a part of a plan
+type PhaseStatus string
+
+const (
+	// PhaseSucceeded phase was executed without errors
+	PhaseSucceeded PhaseStatus = "Succeeded"
+	// PhaseFailed phase execution returned an error
+	PhaseFailed PhaseStatus = "Failed"
+	// PhaseSkipped phase was not executed
+	PhaseSkipped PhaseStatus = "Skipped"
+	// PhaseCancelled phase execution was cancelled or its timeout has expired
+	PhaseCancelled PhaseStatus = "Cancelled"
+)
+
+// PlanRunOptions holds options for plan run
+type PlanRunOptions struct {
+	ifc.RunOptions
+
+	// StartAt is a name of the phase to start plan execution from,
+	// all phases defined before it in the plan are skipped
+	StartAt string
+	// StopAfter is a name of the last phase to execute,
+	// all phases defined after it in the plan are skipped
+	StopAfter string
+	// Concurrency is a maximum number of phases executed simultaneously,
+	// phases are executed one by one if it is not set
+	Concurrency int
+}
+
+// PhaseResult holds the result of a single phase executed as a part of a plan
+type PhaseResult struct {
+	Group    string
+	Phase    string
+	Status   PhaseStatus
+	Duration time.Duration
+	Error    error
+}
+
+// planStep is a phase from a plan along with the group it belongs to
+// and indexes of the steps it depends on
+type planStep struct {
+	group     string
+	phase     string
+	dependsOn []int
+}
+
+// planGraph returns phases of the plan in the order they are defined. Each phase depends
+// on the previous phase in its group and on the phases listed in its dependsOn field
+func planGraph(plan *v1alpha1.PhasePlan) ([]planStep, error) {
+	steps := []planStep{}
+	index := make(map[string]int)
+	for _, group := range plan.PhaseGroups {
+		for i, step := range group.Phases {
+			if _, exists := index[step.Name]; exists {
+				return nil, ErrPhaseDuplicatedInPlan{PhaseName: step.Name, PlanName: plan.Name}
+			}
+			ps := planStep{group: group.Name, phase: step.Name}
+			if i > 0 {
+				ps.dependsOn = append(ps.dependsOn, len(steps)-1)
+			}
+			index[step.Name] = len(steps)
+			steps = append(steps, ps)
+		}
+	}
+
+	for _, group := range plan.PhaseGroups {
+		for _, step := range group.Phases {
+			current := index[step.Name]
+			for _, dep := range step.DependsOn {
+				depIndex, exists := index[dep]
+				if !exists {
+					return nil, ErrUnknownDependency{PhaseName: step.Name, Dependency: dep, PlanName: plan.Name}
+				}
+				steps[current].dependsOn = append(steps[current].dependsOn, depIndex)
+			}
+		}
+	}
+	return steps, checkCycles(plan, steps)
+}
+
+// checkCycles makes sure that phase dependencies don't form a cycle
+func checkCycles(plan *v1alpha1.PhasePlan, steps []planStep) error {
+	const (
+		notVisited = iota
+		inProgress
+		visited
+	)
+	marks := make([]int, len(steps))
+	path := []string{}
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch marks[i] {
+		case visited:
+			return nil
+		case inProgress:
+			// report only phases that form the cycle
+			cycle := []string{}
+			for j := len(path) - 1; j >= 0; j-- {
+				cycle = append([]string{path[j]}, cycle...)
+				if path[j] == steps[i].phase {
+					break
+				}
+			}
+			return ErrDependencyCycle{Phases: append(cycle, steps[i].phase), PlanName: plan.Name}
+		}
+		marks[i] = inProgress
+		path = append(path, steps[i].phase)
+		for _, dep := range steps[i].dependsOn {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		marks[i] = visited
+		return nil
+	}
+
+	for i := range steps {
+		if err := visit(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidatePlan makes sure that phase dependencies defined in the plan are resolvable
+func ValidatePlan(plan *v1alpha1.PhasePlan) error {
+	_, err := planGraph(plan)
+	return err
+}
+
+// planRange returns indexes of the first and the last step of the plan that must be executed
+func planRange(plan *v1alpha1.PhasePlan, steps []planStep, opts PlanRunOptions) (int, int, error) {
+	first, last := 0, len(steps)-1
+	if opts.StartAt != "" {
+		first = stepIndex(steps, opts.StartAt)
+		if first < 0 {
+			return 0, 0, ErrPhaseNotInPlan{PhaseName: opts.StartAt, PlanName: plan.Name}
+		}
+	}
+	if opts.StopAfter != "" {
+		last = stepIndex(steps, opts.StopAfter)
+		if last < 0 {
+			return 0, 0, ErrPhaseNotInPlan{PhaseName: opts.StopAfter, PlanName: plan.Name}
+		}
+	}
+	if first > last {
+		return 0, 0, ErrInvalidPlanRange{StartAt: opts.StartAt, StopAfter: opts.StopAfter}
+	}
+	// phases skipped with start-at option are considered to be completed by previous runs,
+	// but phases skipped with stop-after option are never executed
+	for i := first; i <= last; i++ {
+		for _, dep := range steps[i].dependsOn {
+			if dep > last {
+				return 0, 0, ErrDependencyNotInRange{
+					PhaseName:  steps[i].phase,
+					Dependency: steps[dep].phase,
+					StopAfter:  opts.StopAfter,
+				}
+			}
+		}
+	}
+	return first, last, nil
+}
+
+func stepIndex(steps []planStep, phaseName string) int {
+	for i, step := range steps {
+		if step.phase == phaseName {
+			return i
+		}
+	}
+	return -1
+}
+
+// stepResult is sent by a goroutine running a phase when the phase is finished
+type stepResult struct {
+	index    int
+	duration time.Duration
+	err      error
+}
+
+// RunPlan executes phases defined in the plan. A phase is started as soon as all phases
+// it depends on have succeeded, up to opts.Concurrency phases are executed simultaneously.
+// No new phases are started after the first failure, so the plan is stopped once running phases
+// are cancelled by the context. Result is returned for every phase in the plan
+func RunPlan(
+	ctx context.Context,
+	client ifc.Client,
+	plan *v1alpha1.PhasePlan,
+	opts PlanRunOptions) ([]PhaseResult, error) {
+	steps, err := planGraph(plan)
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) == 0 {
+		return nil, ErrEmptyPlan{PlanName: plan.Name}
+	}
+
+	first, last, err := planRange(plan, steps, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]PhaseResult, len(steps))
+	started := make([]bool, len(steps))
+	for i, step := range steps {
+		results[i] = PhaseResult{Group: step.group, Phase: step.phase, Status: PhaseSkipped}
+	}
+
+	// phases skipped with start-at option are considered to be completed by previous runs
+	satisfied := func(dep int) bool {
+		return dep < first || results[dep].Status == PhaseSucceeded
+	}
+	ready := func(i int) bool {
+		if started[i] || i < first || i > last {
+			return false
+		}
+		for _, dep := range steps[i].dependsOn {
+			if !satisfied(dep) {
+				return false
+			}
+		}
+		return true
+	}
+
+	done := make(chan stepResult, len(steps))
+	running := 0
+	var runErr error
+	for {
+		for i := range steps {
+			if runErr != nil || running >= concurrency {
+				break
+			}
+			if !ready(i) {
+				continue
+			}
+			started[i] = true
+			running++
+			log.Printf("Running phase %s from group %s", steps[i].phase, steps[i].group)
+			go func(i int) {
+				start := time.Now()
+				stepErr := runPlanStep(ctx, client, steps[i], opts.RunOptions)
+				done <- stepResult{index: i, duration: time.Since(start), err: stepErr}
+			}(i)
+		}
+
+		if running == 0 {
+			break
+		}
+
+		res := <-done
+		running--
+		results[res.index].Duration = res.duration
+		if res.err != nil {
+			log.Printf("Phase %s has failed", steps[res.index].phase)
+			results[res.index].Status = PhaseFailed
+			if errors.As(res.err, &ErrPhaseCancelled{}) {
+				results[res.index].Status = PhaseCancelled
+			}
+			results[res.index].Error = res.err
+			if runErr == nil {
+				runErr = res.err
+			}
+			continue
+		}
+		results[res.index].Status = PhaseSucceeded
+	}
+	return results, runErr
+}
+
+func runPlanStep(ctx context.Context, client ifc.Client, step planStep, ro ifc.RunOptions) error {
+	p, err := client.PhaseByID(ifc.ID{Name: step.phase})
+	if err != nil {
+		return err
+	}
+	return p.Run(ctx, ro)
+}
+
+// PrintPlanResults prints a summary of the plan execution
+func PrintPlanResults(results []PhaseResult, w io.Writer) error {
+	tw := util.NewTabWriter(w)
+	defer tw.Flush()
+	fmt.Fprintf(tw, "GROUP\tPHASE\tSTATUS\tDURATION\tERROR\n")
+	for _, result := range results {
+		errMessage := ""
+		if result.Error != nil {
+			// keep every phase on a single line of the table
+			errMessage = strings.ReplaceAll(result.Error.Error(), "\n", " ")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			result.Group,
+			result.Phase,
+			result.Status,
+			result.Duration.Round(time.Second),
+			errMessage)
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/phase"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const planSiteMetaPath = "plan_site/metadata.yaml"
+
+func TestRunPlan(t *testing.T) {
+	tests := []struct {
+		name             string
+		errContains      string
+		plan             *v1alpha1.PhasePlan
+		opts             phase.PlanRunOptions
+		expectedStatuses []phase.PhaseStatus
+	}{
+		{
+			name: "Success all phases",
+			plan: testPlan("phase_one", "phase_two", "phase_three"),
+			expectedStatuses: []phase.PhaseStatus{
+				phase.PhaseSucceeded,
+				phase.PhaseSucceeded,
+				phase.PhaseSucceeded,
+			},
+		},
+		{
+			name: "Success start at and stop after",
+			plan: testPlan("phase_one", "phase_two", "phase_three"),
+			opts: phase.PlanRunOptions{StartAt: "phase_two", StopAfter: "phase_two"},
+			expectedStatuses: []phase.PhaseStatus{
+				phase.PhaseSkipped,
+				phase.PhaseSucceeded,
+				phase.PhaseSkipped,
+			},
+		},
+		{
+			name:        "Error stop at first failed phase",
+			plan:        testPlan("phase_one", "broken_phase", "phase_three"),
+			errContains: "found no documents",
+			expectedStatuses: []phase.PhaseStatus{
+				phase.PhaseSucceeded,
+				phase.PhaseFailed,
+				phase.PhaseSkipped,
+			},
+		},
+		{
+			name:        "Error start at phase not in plan",
+			plan:        testPlan("phase_one"),
+			opts:        phase.PlanRunOptions{StartAt: "phase_two"},
+			errContains: phase.ErrPhaseNotInPlan{PhaseName: "phase_two", PlanName: "test-plan"}.Error(),
+		},
+		{
+			name:        "Error start at is after stop after",
+			plan:        testPlan("phase_one", "phase_two"),
+			opts:        phase.PlanRunOptions{StartAt: "phase_two", StopAfter: "phase_one"},
+			errContains: phase.ErrInvalidPlanRange{StartAt: "phase_two", StopAfter: "phase_one"}.Error(),
+		},
+		{
+			name: "Error dependency is after stop after",
+			plan: testGroupsPlan(
+				v1alpha1.PhaseGroup{Name: "group1", Phases: []v1alpha1.PhaseGroupStep{
+					{Name: "phase_one", DependsOn: []string{"phase_three"}},
+					{Name: "phase_two"},
+				}},
+				v1alpha1.PhaseGroup{Name: "group2", Phases: []v1alpha1.PhaseGroupStep{{Name: "phase_three"}}},
+			),
+			opts: phase.PlanRunOptions{StopAfter: "phase_two"},
+			errContains: phase.ErrDependencyNotInRange{
+				PhaseName:  "phase_one",
+				Dependency: "phase_three",
+				StopAfter:  "phase_two",
+			}.Error(),
+		},
+		{
+			name:        "Error empty plan",
+			plan:        testPlan(),
+			errContains: phase.ErrEmptyPlan{PlanName: "test-plan"}.Error(),
+		},
+		{
+			name: "Success independent groups run concurrently",
+			plan: testGroupsPlan(
+				v1alpha1.PhaseGroup{Name: "group1", Phases: []v1alpha1.PhaseGroupStep{{Name: "phase_one"}}},
+				v1alpha1.PhaseGroup{Name: "group2", Phases: []v1alpha1.PhaseGroupStep{
+					{Name: "phase_two"},
+					{Name: "phase_three", DependsOn: []string{"phase_one"}},
+				}},
+			),
+			opts: phase.PlanRunOptions{Concurrency: 2},
+			expectedStatuses: []phase.PhaseStatus{
+				phase.PhaseSucceeded,
+				phase.PhaseSucceeded,
+				phase.PhaseSucceeded,
+			},
+		},
+		{
+			name: "Error dependent phase is not started",
+			plan: testGroupsPlan(
+				v1alpha1.PhaseGroup{Name: "group1", Phases: []v1alpha1.PhaseGroupStep{{Name: "broken_phase"}}},
+				v1alpha1.PhaseGroup{Name: "group2", Phases: []v1alpha1.PhaseGroupStep{
+					{Name: "phase_one", DependsOn: []string{"broken_phase"}},
+				}},
+			),
+			opts:        phase.PlanRunOptions{Concurrency: 2},
+			errContains: "found no documents",
+			expectedStatuses: []phase.PhaseStatus{
+				phase.PhaseFailed,
+				phase.PhaseSkipped,
+			},
+		},
+		{
+			name: "Error dependency cycle",
+			plan: testGroupsPlan(
+				v1alpha1.PhaseGroup{Name: "group1", Phases: []v1alpha1.PhaseGroupStep{
+					{Name: "phase_one", DependsOn: []string{"phase_three"}},
+					{Name: "phase_two"},
+				}},
+				v1alpha1.PhaseGroup{Name: "group2", Phases: []v1alpha1.PhaseGroupStep{
+					{Name: "phase_three", DependsOn: []string{"phase_two"}},
+				}},
+			),
+			errContains: phase.ErrDependencyCycle{
+				Phases:   []string{"phase_one", "phase_three", "phase_two", "phase_one"},
+				PlanName: "test-plan",
+			}.Error(),
+		},
+		{
+			name: "Error unknown dependency",
+			plan: testGroupsPlan(
+				v1alpha1.PhaseGroup{Name: "group1", Phases: []v1alpha1.PhaseGroupStep{
+					{Name: "phase_one", DependsOn: []string{"some_phase"}},
+				}},
+			),
+			errContains: phase.ErrUnknownDependency{
+				PhaseName:  "phase_one",
+				Dependency: "some_phase",
+				PlanName:   "test-plan",
+			}.Error(),
+		},
+		{
+			name:        "Error phase duplicated in plan",
+			plan:        testPlan("phase_one", "phase_one"),
+			errContains: phase.ErrPhaseDuplicatedInPlan{PhaseName: "phase_one", PlanName: "test-plan"}.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			helper, err := phase.NewHelper(planSiteConfig(t))
+			require.NoError(t, err)
+			client := phase.NewClient(helper, phase.InjectRegistry(fakeRegistry))
+
+			results, err := phase.RunPlan(context.Background(), client, tt.plan, tt.opts)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, results, len(tt.expectedStatuses))
+			for i, result := range results {
+				assert.Equal(t, tt.expectedStatuses[i], result.Status)
+			}
+		})
+	}
+}
+
+func TestRunPlanCancelled(t *testing.T) {
+	helper, err := phase.NewHelper(planSiteConfig(t))
+	require.NoError(t, err)
+	client := phase.NewClient(helper, phase.InjectRegistry(blockingRegistry))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := phase.RunPlan(ctx, client, testPlan("phase_one", "phase_two"), phase.PlanRunOptions{})
+	assert.Equal(t, phase.ErrPhaseCancelled{PhaseName: "phase_one", Err: context.Canceled}, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, phase.PhaseCancelled, results[0].Status)
+	assert.Equal(t, phase.PhaseSkipped, results[1].Status)
+}
+
+func TestRunPlanWaitTimeout(t *testing.T) {
+	helper, err := phase.NewHelper(planSiteConfig(t))
+	require.NoError(t, err)
+	merger := events.NewMerger(&recordingProcessor{})
+	client := phase.NewClient(helper,
+		phase.InjectRegistry(waitTimeoutRegistry("phase_one")),
+		phase.InjectProcessor(merger.Processor))
+
+	plan := testGroupsPlan(
+		v1alpha1.PhaseGroup{Name: "group1", Phases: []v1alpha1.PhaseGroupStep{{Name: "phase_one"}}},
+		v1alpha1.PhaseGroup{Name: "group2", Phases: []v1alpha1.PhaseGroupStep{
+			{Name: "phase_two", DependsOn: []string{"phase_one"}},
+		}},
+	)
+	results, err := phase.RunPlan(context.Background(), client, plan, phase.PlanRunOptions{Concurrency: 2})
+	require.NoError(t, merger.Close())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "resources are not ready")
+	require.Len(t, results, 2)
+	assert.Equal(t, phase.PhaseFailed, results[0].Status)
+	assert.Equal(t, phase.PhaseSkipped, results[1].Status)
+}
+
+// waitTimeoutRegistry returns executors which report wait timeout for the given phase
+func waitTimeoutRegistry(phaseName string) func() map[schema.GroupVersionKind]ifc.ExecutorFactory {
+	return func() map[schema.GroupVersionKind]ifc.ExecutorFactory {
+		gvk := schema.GroupVersionKind{
+			Group:   "airshipit.org",
+			Version: "v1alpha1",
+			Kind:    "Clusterctl",
+		}
+		return map[schema.GroupVersionKind]ifc.ExecutorFactory{
+			gvk: func(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
+				if cfg.PhaseName == phaseName {
+					return waitTimeoutExecutor{}, nil
+				}
+				return fakeExecutor{}, nil
+			},
+		}
+	}
+}
+
+type waitTimeoutExecutor struct {
+	fakeExecutor
+}
+
+func (e waitTimeoutExecutor) Run(_ context.Context, ch chan events.Event, _ ifc.RunOptions) {
+	defer close(ch)
+	ch <- events.Event{
+		Type: events.WaitType,
+		WaitEvent: events.WaitEvent{
+			Operation: events.WaitTimeout,
+			Error:     errors.New("resources are not ready"),
+		},
+	}
+}
+
+func TestPrintPlanResults(t *testing.T) {
+	results := []phase.PhaseResult{
+		{Group: "group1", Phase: "phase_one", Status: phase.PhaseSucceeded},
+		{Group: "group1", Phase: "phase_two", Status: phase.PhaseSkipped},
+		{Group: "group2", Phase: "phase_three", Status: phase.PhaseFailed, Error: fmt.Errorf("first line\nsecond line")},
+	}
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, phase.PrintPlanResults(results, buf))
+	assert.Contains(t, buf.String(), "ERROR")
+	assert.Contains(t, buf.String(), "first line second line")
+	assert.Contains(t, buf.String(), "phase_one")
+	assert.Contains(t, buf.String(), "Succeeded")
+	assert.Contains(t, buf.String(), "phase_two")
+	assert.Contains(t, buf.String(), "Skipped")
+}
+
+func testPlan(phases ...string) *v1alpha1.PhasePlan {
+	plan := &v1alpha1.PhasePlan{}
+	plan.Name = "test-plan"
+	if len(phases) == 0 {
+		return plan
+	}
+	group := v1alpha1.PhaseGroup{Name: "group1"}
+	for _, p := range phases {
+		group.Phases = append(group.Phases, v1alpha1.PhaseGroupStep{Name: p})
+	}
+	plan.PhaseGroups = []v1alpha1.PhaseGroup{group}
+	return plan
+}
+
+func testGroupsPlan(groups ...v1alpha1.PhaseGroup) *v1alpha1.PhasePlan {
+	plan := &v1alpha1.PhasePlan{PhaseGroups: groups}
+	plan.Name = "test-plan"
+	return plan
+}
+
+func planSiteConfig(t *testing.T) *config.Config {
+	t.Helper()
+	conf := testConfig(t)
+	conf.Manifests["dummy_manifest"].MetadataPath = planSiteMetaPath
+	return conf
+}
@@ -0,0 +1,73 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package plugin
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrPluginFailed returned if executor plugin exited with an error
+type ErrPluginFailed struct {
+	Plugin string
+	Err    error
+}
+
+func (e ErrPluginFailed) Error() string {
+	return fmt.Sprintf("executor plugin %s has failed: %v", e.Plugin, e.Err)
+}
+
+// ErrPluginReported returned if executor plugin responded with an error
+type ErrPluginReported struct {
+	Plugin  string
+	Message string
+}
+
+func (e ErrPluginReported) Error() string {
+	return fmt.Sprintf("executor plugin %s returned an error: %s", e.Plugin, e.Message)
+}
+
+// ErrPluginTimedOut returned if executor plugin hasn't finished the operation in time
+type ErrPluginTimedOut struct {
+	Plugin    string
+	Operation Operation
+	Timeout   time.Duration
+}
+
+func (e ErrPluginTimedOut) Error() string {
+	return fmt.Sprintf("executor plugin %s hasn't finished %s operation in %s", e.Plugin, e.Operation, e.Timeout)
+}
+
+// ErrMalformedResponse returned if executor plugin output doesn't follow the protocol
+type ErrMalformedResponse struct {
+	Plugin string
+	Line   string
+}
+
+func (e ErrMalformedResponse) Error() string {
+	return fmt.Sprintf("executor plugin %s returned malformed response '%s', "+
+		"each line of the output must be a JSON object with type and message fields", e.Plugin, e.Line)
+}
+
+// ErrUnknownResponseType returned if executor plugin responded with unsupported type
+type ErrUnknownResponseType struct {
+	Plugin string
+	Type   ResponseType
+}
+
+func (e ErrUnknownResponseType) Error() string {
+	return fmt.Sprintf("executor plugin %s returned response of unknown type '%s', supported types are: %s, %s",
+		e.Plugin, e.Type, ResponseMessage, ResponseError)
+}
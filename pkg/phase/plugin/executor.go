@@ -0,0 +1,282 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const (
+	// maxResponseSize is a maximum length of a single line of the plugin output
+	maxResponseSize = 1024 * 1024
+	// DefaultCallTimeout limits validate and details operations of the plugin, run operation
+	// is limited by the phase timeout instead
+	DefaultCallTimeout = 2 * time.Minute
+)
+
+var _ ifc.Executor = &Executor{}
+
+// Executor runs an external executable as a phase executor. Executable gets a Request
+// as a JSON object on its STDIN and reports the results of the operation by writing
+// Response JSON objects, one per line, to its STDOUT. Plugin STDERR is written to airshipctl log
+type Executor struct {
+	ExecutorBundle   document.Bundle
+	ExecutorDocument document.Document
+	// CallTimeout is a maximum duration of validate and details operations
+	CallTimeout time.Duration
+
+	path              string
+	phaseName         string
+	clusterName       string
+	kubeconfigContext string
+	kubeconfig        kubeconfig.Interface
+}
+
+// NewExecutor creates instance of phase executor backed by the plugin executable
+func NewExecutor(path string, cfg ifc.ExecutorConfig) (ifc.Executor, error) {
+	bundle, err := cfg.BundleFactory()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Executor{
+		ExecutorBundle:    bundle,
+		ExecutorDocument:  cfg.ExecutorDocument,
+		CallTimeout:       DefaultCallTimeout,
+		path:              path,
+		phaseName:         cfg.PhaseName,
+		clusterName:       cfg.ClusterName,
+		kubeconfigContext: cfg.KubeconfigContext,
+		kubeconfig:        cfg.KubeConfig,
+	}, nil
+}
+
+// Run executes the plugin, messages reported by the plugin are sent as executor plugin events
+func (e *Executor) Run(ctx context.Context, evtCh chan events.Event, opts ifc.RunOptions) {
+	defer close(evtCh)
+
+	evtCh <- events.Event{
+		Type: events.ExecutorPluginType,
+		ExecutorPluginEvent: events.ExecutorPluginEvent{
+			Operation: events.ExecutorPluginStart,
+			Message:   fmt.Sprintf("starting executor plugin %s", e.name()),
+		},
+	}
+
+	req, err := e.request(OperationRun)
+	if err != nil {
+		handleError(evtCh, err)
+		return
+	}
+	req.DryRun = opts.DryRun
+
+	if e.clusterName != "" && e.kubeconfig != nil && !opts.DryRun {
+		path, cleanup, kubeconfigErr := e.kubeconfig.GetFile()
+		if kubeconfigErr != nil {
+			handleError(evtCh, kubeconfigErr)
+			return
+		}
+		defer cleanup()
+		req.KubeconfigPath = path
+		req.KubeconfigContext = e.kubeconfigContext
+	}
+
+	err = e.call(ctx, req, func(message string) {
+		evtCh <- events.Event{
+			Type: events.ExecutorPluginType,
+			ExecutorPluginEvent: events.ExecutorPluginEvent{
+				Operation: events.ExecutorPluginMessage,
+				Message:   message,
+			},
+		}
+	})
+	if err != nil {
+		handleError(evtCh, err)
+		return
+	}
+
+	evtCh <- events.Event{
+		Type: events.ExecutorPluginType,
+		ExecutorPluginEvent: events.ExecutorPluginEvent{
+			Operation: events.ExecutorPluginEnd,
+			Message:   fmt.Sprintf("executor plugin %s is finished", e.name()),
+		},
+	}
+}
+
+// Validate asks the plugin to validate executor document and phase documents
+func (e *Executor) Validate() error {
+	req, err := e.request(OperationValidate)
+	if err != nil {
+		return err
+	}
+	return e.callWithTimeout(req, func(message string) {
+		log.Debugf("Executor plugin %s: %s", e.name(), message)
+	})
+}
+
+// Details returns phase description reported by the plugin
+func (e *Executor) Details() (string, error) {
+	req, err := e.request(OperationDetails)
+	if err != nil {
+		return "", err
+	}
+	details := []string{}
+	err = e.callWithTimeout(req, func(message string) {
+		details = append(details, message)
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(details, "\n"), nil
+}
+
+// Render documents passed to the plugin
+func (e *Executor) Render(w io.Writer, o ifc.RenderOptions) error {
+	bundle, err := e.ExecutorBundle.SelectBundle(o.FilterSelector)
+	if err != nil {
+		return err
+	}
+	return bundle.Write(w)
+}
+
+// name returns plugin name for log messages
+func (e *Executor) name() string {
+	return filepath.Base(e.path)
+}
+
+func (e *Executor) request(op Operation) (Request, error) {
+	executorDoc, err := e.ExecutorDocument.AsYAML()
+	if err != nil {
+		return Request{}, err
+	}
+	docs := &bytes.Buffer{}
+	if err = e.ExecutorBundle.Write(docs); err != nil {
+		return Request{}, err
+	}
+	return Request{
+		Operation:        op,
+		PhaseName:        e.phaseName,
+		ClusterName:      e.clusterName,
+		ExecutorDocument: string(executorDoc),
+		Documents:        docs.String(),
+	}, nil
+}
+
+// callWithTimeout calls the plugin with the request, plugin is killed if it's not finished within CallTimeout
+func (e *Executor) callWithTimeout(req Request, onMessage func(string)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), e.CallTimeout)
+	defer cancel()
+	err := e.call(ctx, req, onMessage)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return ErrPluginTimedOut{Plugin: e.path, Operation: req.Operation, Timeout: e.CallTimeout}
+	}
+	return err
+}
+
+// call executes the plugin with the request and passes reported messages to onMessage function.
+// Plugin is killed if the context is cancelled, the first error reported by the plugin is returned
+func (e *Executor) call(ctx context.Context, req Request, onMessage func(string)) error {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	// plugin path comes from the plugin directory, which is trusted the same way as kustomize plugins
+	cmd := exec.CommandContext(ctx, e.path) //nolint:gosec
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stderr = log.Writer()
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	log.Debugf("Running executor plugin %s with %s operation", e.path, req.Operation)
+	if err = cmd.Start(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return ErrPluginFailed{Plugin: e.path, Err: err}
+	}
+
+	var respErr error
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxResponseSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		// the rest of the output is drained after the first error, so the plugin is not blocked on write
+		if len(line) == 0 || respErr != nil {
+			continue
+		}
+		respErr = e.handleResponse(line, onMessage)
+	}
+	if scanErr := scanner.Err(); scanErr != nil {
+		// scanner stops on the first oversized line, drain the rest so the plugin is not blocked on write
+		io.Copy(ioutil.Discard, stdout) //nolint:errcheck
+		if respErr == nil {
+			respErr = scanErr
+		}
+	}
+
+	if err = cmd.Wait(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if respErr == nil {
+			respErr = ErrPluginFailed{Plugin: e.path, Err: err}
+		}
+	}
+	return respErr
+}
+
+func (e *Executor) handleResponse(line []byte, onMessage func(string)) error {
+	resp := Response{}
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return ErrMalformedResponse{Plugin: e.path, Line: string(line)}
+	}
+	switch resp.Type {
+	case ResponseMessage:
+		onMessage(resp.Message)
+		return nil
+	case ResponseError:
+		return ErrPluginReported{Plugin: e.path, Message: resp.Message}
+	default:
+		return ErrUnknownResponseType{Plugin: e.path, Type: resp.Type}
+	}
+}
+
+func handleError(ch chan<- events.Event, err error) {
+	ch <- events.Event{
+		Type: events.ErrorType,
+		ErrorEvent: events.ErrorEvent{
+			Error: err,
+		},
+	}
+}
@@ -0,0 +1,288 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package plugin_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/pkg/phase/plugin"
+	"opendev.org/airship/airshipctl/testutil"
+)
+
+const (
+	stubPlugin = "testdata/plugins/example.com/v1alpha1/stubexecutor/StubExecutor"
+
+	executorDoc = `apiVersion: example.com/v1alpha1
+kind: StubExecutor
+metadata:
+  name: stub
+spec:
+  key: value
+`
+	inputDocs = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: input
+data:
+  key: value
+`
+)
+
+func testExecutor(t *testing.T, kubeconf kubeconfig.Interface) ifc.Executor {
+	execDoc, err := document.NewDocumentFromBytes([]byte(executorDoc))
+	require.NoError(t, err)
+	executor, err := plugin.NewExecutor(stubPlugin, ifc.ExecutorConfig{
+		PhaseName:         "stub-phase",
+		ClusterName:       "target-cluster",
+		KubeconfigContext: "target-cluster",
+		ExecutorDocument:  execDoc,
+		KubeConfig:        kubeconf,
+		BundleFactory: func() (document.Bundle, error) {
+			return document.NewBundleFromBytes([]byte(inputDocs))
+		},
+	})
+	require.NoError(t, err)
+	return executor
+}
+
+// setStubEnv sets environment variables which control the stub plugin behavior
+func setStubEnv(t *testing.T, env map[string]string) func() {
+	t.Helper()
+	for key, val := range env {
+		require.NoError(t, os.Setenv(key, val))
+	}
+	return func() {
+		for key := range env {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func pluginEvent(op events.ExecutorPluginOperation, message string) events.Event {
+	return events.Event{
+		Type: events.ExecutorPluginType,
+		ExecutorPluginEvent: events.ExecutorPluginEvent{
+			Operation: op,
+			Message:   message,
+		},
+	}
+}
+
+func TestExecutorRun(t *testing.T) {
+	testCases := []struct {
+		name          string
+		env           map[string]string
+		runOptions    ifc.RunOptions
+		expectedEvt   []events.Event
+		expectedErr   error
+		errorContains string
+	}{
+		{
+			name: "plugin messages are sent as events",
+			expectedEvt: []events.Event{
+				pluginEvent(events.ExecutorPluginStart, "starting executor plugin StubExecutor"),
+				pluginEvent(events.ExecutorPluginMessage, "applying documents"),
+				pluginEvent(events.ExecutorPluginMessage, "documents applied"),
+				pluginEvent(events.ExecutorPluginEnd, "executor plugin StubExecutor is finished"),
+			},
+		},
+		{
+			name: "plugin reports an error",
+			env:  map[string]string{"STUB_ERROR": "apply failed"},
+			expectedEvt: []events.Event{
+				pluginEvent(events.ExecutorPluginStart, "starting executor plugin StubExecutor"),
+				pluginEvent(events.ExecutorPluginMessage, "applying documents"),
+				pluginEvent(events.ExecutorPluginMessage, "documents applied"),
+			},
+			expectedErr: plugin.ErrPluginReported{Plugin: stubPlugin, Message: "apply failed"},
+		},
+		{
+			name: "plugin exits with non-zero code",
+			env:  map[string]string{"STUB_EXIT_CODE": "3"},
+			expectedEvt: []events.Event{
+				pluginEvent(events.ExecutorPluginStart, "starting executor plugin StubExecutor"),
+				pluginEvent(events.ExecutorPluginMessage, "applying documents"),
+				pluginEvent(events.ExecutorPluginMessage, "documents applied"),
+			},
+			errorContains: "exit status 3",
+		},
+		{
+			name: "plugin prints a line over the response size limit",
+			env:  map[string]string{"STUB_LONG_LINE": "2097152"},
+			expectedEvt: []events.Event{
+				pluginEvent(events.ExecutorPluginStart, "starting executor plugin StubExecutor"),
+				pluginEvent(events.ExecutorPluginMessage, "applying documents"),
+				pluginEvent(events.ExecutorPluginMessage, "documents applied"),
+			},
+			expectedErr: bufio.ErrTooLong,
+		},
+	}
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			defer setStubEnv(t, tt.env)()
+			executor := testExecutor(t, nil)
+			ch := make(chan events.Event)
+			go executor.Run(context.Background(), ch, tt.runOptions)
+			var actualEvt []events.Event
+			var actualErr error
+			for evt := range ch {
+				if evt.Type == events.ErrorType {
+					actualErr = evt.ErrorEvent.Error
+					continue
+				}
+				actualEvt = append(actualEvt, evt)
+			}
+			assert.Equal(t, tt.expectedEvt, actualEvt)
+			switch {
+			case tt.errorContains != "":
+				require.Error(t, actualErr)
+				assert.Contains(t, actualErr.Error(), tt.errorContains)
+			default:
+				assert.Equal(t, tt.expectedErr, actualErr)
+			}
+		})
+	}
+}
+
+func TestExecutorRunRequest(t *testing.T) {
+	tmpDir, cleanup := testutil.TempDir(t, "executor-plugin-test")
+	defer cleanup(t)
+	requestFile := filepath.Join(tmpDir, "request.json")
+	kubeconfigPath := filepath.Join(tmpDir, "kubeconfig")
+	defer setStubEnv(t, map[string]string{"STUB_REQUEST_FILE": requestFile})()
+
+	testCases := []struct {
+		name            string
+		runOptions      ifc.RunOptions
+		expectedRequest plugin.Request
+	}{
+		{
+			name: "kubeconfig is passed to the plugin",
+			expectedRequest: plugin.Request{
+				Operation:         plugin.OperationRun,
+				PhaseName:         "stub-phase",
+				ClusterName:       "target-cluster",
+				KubeconfigPath:    kubeconfigPath,
+				KubeconfigContext: "target-cluster",
+			},
+		},
+		{
+			name:       "kubeconfig is not passed in dry-run mode",
+			runOptions: ifc.RunOptions{DryRun: true},
+			expectedRequest: plugin.Request{
+				Operation:   plugin.OperationRun,
+				PhaseName:   "stub-phase",
+				ClusterName: "target-cluster",
+				DryRun:      true,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			kubeconf := kubeconfig.NewKubeConfig(
+				kubeconfig.FromByte(nil),
+				kubeconfig.InjectFilePath(kubeconfigPath, nil))
+			ch := make(chan events.Event)
+			go testExecutor(t, kubeconf).Run(context.Background(), ch, tt.runOptions)
+			for evt := range ch {
+				require.NotEqual(t, events.ErrorType, evt.Type)
+			}
+
+			data, err := ioutil.ReadFile(requestFile)
+			require.NoError(t, err)
+			actualRequest := plugin.Request{}
+			require.NoError(t, json.Unmarshal(data, &actualRequest))
+			// plugin must receive executor document and documents of the phase
+			assert.Contains(t, actualRequest.ExecutorDocument, "kind: StubExecutor")
+			assert.Contains(t, actualRequest.Documents, "name: input")
+			actualRequest.ExecutorDocument, actualRequest.Documents = "", ""
+			assert.Equal(t, tt.expectedRequest, actualRequest)
+		})
+	}
+}
+
+func TestExecutorRunCancelled(t *testing.T) {
+	defer setStubEnv(t, map[string]string{"STUB_SLEEP": "30"})()
+	executor := testExecutor(t, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	ch := make(chan events.Event)
+	start := time.Now()
+	go executor.Run(ctx, ch, ifc.RunOptions{})
+	var actualEvt []events.Event
+	for evt := range ch {
+		actualEvt = append(actualEvt, evt)
+	}
+	// plugin must be killed when the context is cancelled
+	assert.True(t, time.Since(start) < 10*time.Second)
+	require.Len(t, actualEvt, 2)
+	assert.Equal(t, events.ErrorEvent{Error: context.DeadlineExceeded}, actualEvt[1].ErrorEvent)
+}
+
+func TestExecutorValidate(t *testing.T) {
+	executor := testExecutor(t, nil)
+	assert.NoError(t, executor.Validate())
+
+	defer setStubEnv(t, map[string]string{"STUB_ERROR": "spec.key is invalid"})()
+	assert.Equal(t, plugin.ErrPluginReported{Plugin: stubPlugin, Message: "spec.key is invalid"}, executor.Validate())
+}
+
+func TestExecutorValidateTimeout(t *testing.T) {
+	defer setStubEnv(t, map[string]string{"STUB_SLEEP": "30"})()
+	executor := testExecutor(t, nil)
+	pluginExecutor, ok := executor.(*plugin.Executor)
+	require.True(t, ok)
+	pluginExecutor.CallTimeout = 100 * time.Millisecond
+
+	start := time.Now()
+	err := executor.Validate()
+	// plugin must be killed when the call timeout expires
+	assert.True(t, time.Since(start) < 10*time.Second)
+	assert.Equal(t, plugin.ErrPluginTimedOut{
+		Plugin:    stubPlugin,
+		Operation: plugin.OperationValidate,
+		Timeout:   100 * time.Millisecond,
+	}, err)
+}
+
+func TestExecutorDetails(t *testing.T) {
+	details, err := testExecutor(t, nil).Details()
+	require.NoError(t, err)
+	assert.Equal(t, "applies documents with stub executor", details)
+}
+
+func TestExecutorRender(t *testing.T) {
+	out := &bytes.Buffer{}
+	require.NoError(t, testExecutor(t, nil).Render(out, ifc.RenderOptions{}))
+	assert.Contains(t, out.String(), "name: input")
+}
@@ -0,0 +1,138 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/pkg/util"
+)
+
+// Operation is a name of the action requested from the executor plugin
+type Operation string
+
+const (
+	// OperationRun requests the plugin to run the phase
+	OperationRun Operation = "run"
+	// OperationValidate requests the plugin to validate executor document and phase documents
+	OperationValidate Operation = "validate"
+	// OperationDetails requests the plugin to describe what it does when the phase is run
+	OperationDetails Operation = "details"
+)
+
+// ResponseType is a type of the line written by the executor plugin to its STDOUT
+type ResponseType string
+
+const (
+	// ResponseMessage is an informational message, it is reported as an executor plugin event
+	// during the run and as a part of phase description for details operation
+	ResponseMessage ResponseType = "message"
+	// ResponseError means that requested operation has failed
+	ResponseError ResponseType = "error"
+)
+
+// Request is written by airshipctl to the STDIN of the executor plugin as a single JSON object
+type Request struct {
+	Operation   Operation `json:"operation"`
+	PhaseName   string    `json:"phaseName"`
+	ClusterName string    `json:"clusterName,omitempty"`
+	// KubeconfigPath is a path to kubeconfig of the phase cluster, it is set for run
+	// operation only if phase defines cluster name and dry run is not requested
+	KubeconfigPath    string `json:"kubeconfigPath,omitempty"`
+	KubeconfigContext string `json:"kubeconfigContext,omitempty"`
+	DryRun            bool   `json:"dryRun,omitempty"`
+	// ExecutorDocument is a YAML of the document referenced by the phase executorRef
+	ExecutorDocument string `json:"executorDocument"`
+	// Documents is a multi-document YAML of the phase document entrypoint
+	Documents string `json:"documents"`
+}
+
+// Response is written by the executor plugin to its STDOUT, one JSON object per line
+type Response struct {
+	Type    ResponseType `json:"type"`
+	Message string       `json:"message"`
+}
+
+// Path returns the location to look for executor plugins in. The location is taken from
+// AIRSHIP_EXECUTOR_PLUGINS environment variable, ~/.airship/executor-plugins is used by default
+func Path() string {
+	if path := os.Getenv(config.AirshipExecutorPluginPathEnv); path != "" {
+		return path
+	}
+	return filepath.Join(util.UserHomeDir(), config.AirshipConfigDir, config.AirshipExecutorPluginPath)
+}
+
+// RegisterExecutors adds executor plugins found in the directory to phase executor registry.
+// Plugins are expected to be laid out the same way as kustomize exec plugins, i.e. plugin for
+// example.com/v1alpha1 MyExecutor kind is an executable file <dir>/example.com/v1alpha1/myexecutor/MyExecutor.
+// Plugins can't override executors which are already registered
+func RegisterExecutors(registry map[schema.GroupVersionKind]ifc.ExecutorFactory, dir string) error {
+	plugins, err := discover(dir)
+	if err != nil {
+		return err
+	}
+	for gvk, path := range plugins {
+		if _, exists := registry[gvk]; exists {
+			log.Debugf("Executor for %s is already registered, skipping plugin %s", gvk, path)
+			continue
+		}
+		registry[gvk] = factory(path)
+	}
+	return nil
+}
+
+func factory(path string) ifc.ExecutorFactory {
+	return func(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
+		return NewExecutor(path, cfg)
+	}
+}
+
+// discover returns paths to executable plugin files found in the directory by their GVK,
+// missing directory means that no plugins are installed. Entries which can't be read, e.g.
+// dangling symlinks, are skipped, so that a broken plugin doesn't affect other executors
+func discover(dir string) (map[schema.GroupVersionKind]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*", "*", "*", "*"))
+	if err != nil {
+		return nil, err
+	}
+	plugins := make(map[schema.GroupVersionKind]string)
+	for _, path := range paths {
+		info, statErr := os.Stat(path)
+		if statErr != nil {
+			log.Printf("Skipping executor plugin %s: %v", path, statErr)
+			continue
+		}
+		// <group>/<version>/<lowercase kind>/<kind>
+		kindDir, kind := filepath.Base(filepath.Dir(path)), filepath.Base(path)
+		if !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 || strings.ToLower(kind) != kindDir {
+			continue
+		}
+		versionDir := filepath.Dir(filepath.Dir(path))
+		gvk := schema.GroupVersionKind{
+			Group:   filepath.Base(filepath.Dir(versionDir)),
+			Version: filepath.Base(versionDir),
+			Kind:    kind,
+		}
+		plugins[gvk] = path
+	}
+	return plugins, nil
+}
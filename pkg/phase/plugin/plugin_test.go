@@ -0,0 +1,98 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package plugin_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/pkg/phase/plugin"
+	"opendev.org/airship/airshipctl/testutil"
+)
+
+var stubGVK = schema.GroupVersionKind{
+	Group:   "example.com",
+	Version: "v1alpha1",
+	Kind:    "StubExecutor",
+}
+
+func TestPath(t *testing.T) {
+	oldPath := os.Getenv(config.AirshipExecutorPluginPathEnv)
+	defer os.Setenv(config.AirshipExecutorPluginPathEnv, oldPath)
+
+	os.Setenv(config.AirshipExecutorPluginPathEnv, "/tmp/executor-plugins")
+	assert.Equal(t, "/tmp/executor-plugins", plugin.Path())
+
+	os.Setenv(config.AirshipExecutorPluginPathEnv, "")
+	assert.Equal(t, config.AirshipExecutorPluginPath, filepath.Base(plugin.Path()))
+}
+
+func TestRegisterExecutors(t *testing.T) {
+	testCases := []struct {
+		name         string
+		dir          string
+		registry     map[schema.GroupVersionKind]ifc.ExecutorFactory
+		expectedGVKs []schema.GroupVersionKind
+	}{
+		{
+			name:         "register executable plugins",
+			dir:          "testdata/plugins",
+			registry:     make(map[schema.GroupVersionKind]ifc.ExecutorFactory),
+			expectedGVKs: []schema.GroupVersionKind{stubGVK},
+		},
+		{
+			name:         "missing plugin directory",
+			dir:          "testdata/does-not-exist",
+			registry:     make(map[schema.GroupVersionKind]ifc.ExecutorFactory),
+			expectedGVKs: []schema.GroupVersionKind{},
+		},
+	}
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, plugin.RegisterExecutors(tt.registry, tt.dir))
+			gvks := []schema.GroupVersionKind{}
+			for gvk := range tt.registry {
+				gvks = append(gvks, gvk)
+			}
+			assert.ElementsMatch(t, tt.expectedGVKs, gvks)
+		})
+	}
+
+	// plugins can't override executors registered before them
+	registry := map[schema.GroupVersionKind]ifc.ExecutorFactory{stubGVK: nil}
+	require.NoError(t, plugin.RegisterExecutors(registry, "testdata/plugins"))
+	assert.Nil(t, registry[stubGVK])
+}
+
+func TestRegisterExecutorsBrokenPlugin(t *testing.T) {
+	dir, cleanup := testutil.TempDir(t, "executor-plugins")
+	defer cleanup(t)
+
+	kindDir := filepath.Join(dir, "example.com", "v1alpha1", "brokenexecutor")
+	require.NoError(t, os.MkdirAll(kindDir, 0755))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "does-not-exist"), filepath.Join(kindDir, "BrokenExecutor")))
+
+	registry := make(map[schema.GroupVersionKind]ifc.ExecutorFactory)
+	require.NoError(t, plugin.RegisterExecutors(registry, dir))
+	assert.Empty(t, registry)
+}
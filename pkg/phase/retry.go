@@ -0,0 +1,234 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/k8s/poller"
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote"
+	"opendev.org/airship/airshipctl/pkg/remote/redfish"
+)
+
+// runExecutor runs the executor according to the retry policy of the phase and sends its events
+// to the channel. Error events of the attempts which are retried are replaced by retry events,
+// so only errors of the last attempt are reported. True is returned if the executor has succeeded
+func (p *phase) runExecutor(
+	ctx context.Context,
+	executor ifc.Executor,
+	ch chan<- events.Event,
+	ro ifc.RunOptions) bool {
+	policy := p.apiObj.Config.Retry
+	maxAttempts := 1
+	if policy != nil && policy.MaxAttempts > 1 {
+		maxAttempts = policy.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			// executor is created again, so that no state is left from the failed attempt
+			var err error
+			if executor, err = p.Executor(); err != nil {
+				ch <- events.Event{Type: events.ErrorType, ErrorEvent: events.ErrorEvent{Error: err}}
+				return false
+			}
+		}
+		if maxAttempts > 1 {
+			ch <- retryEvent(events.PhaseRetryAttemptStart, attempt, maxAttempts,
+				fmt.Sprintf("running attempt %d of %d", attempt, maxAttempts))
+		}
+
+		errEvents := runAttempt(ctx, executor, ch, ro)
+		if len(errEvents) == 0 {
+			return true
+		}
+		if attempt >= maxAttempts || ctx.Err() != nil || !retryable(policy, errEvents) {
+			forward(ch, errEvents)
+			return false
+		}
+
+		delay := retryDelay(policy, attempt)
+		ch <- retryEvent(events.PhaseRetryAttemptFailed, attempt, maxAttempts,
+			fmt.Sprintf("attempt %d of %d has failed: %v, retrying in %s",
+				attempt, maxAttempts, eventErrors(errEvents), delay))
+		select {
+		case <-ctx.Done():
+			log.Printf("Phase %s is cancelled, attempt %d is not retried", p.apiObj.Name, attempt)
+			forward(ch, errEvents)
+			return false
+		case <-time.After(delay):
+		}
+	}
+}
+
+// validateRetry makes sure that retry policy of the phase has no negative values and known error types
+func (p *phase) validateRetry() error {
+	policy := p.apiObj.Config.Retry
+	if policy == nil {
+		return nil
+	}
+	invalid := func(reason string) error {
+		return ErrInvalidRetryPolicy{PhaseName: p.apiObj.Name, Reason: reason}
+	}
+	if policy.MaxAttempts < 0 || policy.Backoff < 0 || policy.MaxBackoff < 0 {
+		return invalid("maxAttempts, backoff and maxBackoff must not be negative")
+	}
+	for _, errType := range policy.RetryOn {
+		switch errType {
+		case v1alpha1.PhaseRetryOnAny, v1alpha1.PhaseRetryOnNetwork, v1alpha1.PhaseRetryOnTimeout,
+			v1alpha1.PhaseRetryOnServerError, v1alpha1.PhaseRetryOnConflict:
+		default:
+			return invalid(fmt.Sprintf("unknown error type %s, must be one of: %s, %s, %s, %s, %s", errType,
+				v1alpha1.PhaseRetryOnAny, v1alpha1.PhaseRetryOnNetwork, v1alpha1.PhaseRetryOnTimeout,
+				v1alpha1.PhaseRetryOnServerError, v1alpha1.PhaseRetryOnConflict))
+		}
+	}
+	return nil
+}
+
+// runAttempt runs the executor, forwards its events to the channel and returns error events
+func runAttempt(ctx context.Context, executor ifc.Executor, ch chan<- events.Event, ro ifc.RunOptions) []events.Event {
+	executorCh := make(chan events.Event)
+	go executor.Run(ctx, executorCh, ro)
+	errEvents := []events.Event{}
+	for e := range executorCh {
+		if isErrorEvent(e) {
+			errEvents = append(errEvents, e)
+			continue
+		}
+		ch <- e
+	}
+	return errEvents
+}
+
+func forward(ch chan<- events.Event, evts []events.Event) {
+	for _, e := range evts {
+		ch <- e
+	}
+}
+
+// retryable returns true if errors of all error events are retried by the policy
+func retryable(policy *v1alpha1.PhaseRetryPolicy, errEvents []events.Event) bool {
+	if policy == nil {
+		return false
+	}
+	if len(policy.RetryOn) == 0 {
+		return true
+	}
+	for _, err := range eventErrors(errEvents) {
+		matched := false
+		for _, errType := range policy.RetryOn {
+			if isRetryableError(err, errType) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func isRetryableError(err error, errType v1alpha1.PhaseRetryErrorType) bool {
+	switch errType {
+	case v1alpha1.PhaseRetryOnAny:
+		return true
+	case v1alpha1.PhaseRetryOnNetwork:
+		var netErr net.Error
+		return errors.As(err, &netErr) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET)
+	case v1alpha1.PhaseRetryOnTimeout:
+		return isTimeoutError(err)
+	case v1alpha1.PhaseRetryOnServerError:
+		code := apiStatusCode(err)
+		return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+	case v1alpha1.PhaseRetryOnConflict:
+		return apiStatusCode(err) == http.StatusConflict
+	}
+	return false
+}
+
+func isTimeoutError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var status apierror.APIStatus
+	if errors.As(err, &status) {
+		reason := status.Status().Reason
+		return reason == metav1.StatusReasonTimeout || reason == metav1.StatusReasonServerTimeout
+	}
+	return errors.As(err, &poller.ErrWaitTimeout{}) ||
+		errors.As(err, &remote.ErrPowerStateTimeout{}) ||
+		errors.As(err, &redfish.ErrOperationRetriesExceeded{})
+}
+
+// apiStatusCode returns HTTP code of kubernetes API error, 0 is returned for other errors
+func apiStatusCode(err error) int32 {
+	var status apierror.APIStatus
+	if errors.As(err, &status) {
+		return status.Status().Code
+	}
+	return 0
+}
+
+// retryDelay returns the delay before the next attempt, which is doubled after every retry
+func retryDelay(policy *v1alpha1.PhaseRetryPolicy, attempt int) time.Duration {
+	delay := time.Duration(policy.Backoff) * time.Second
+	maxDelay := time.Duration(policy.MaxBackoff) * time.Second
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if maxDelay > 0 && delay >= maxDelay {
+			break
+		}
+	}
+	if maxDelay > 0 && delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+func eventErrors(errEvents []events.Event) []error {
+	errs := []error{}
+	for _, e := range errEvents {
+		errs = append(errs, events.ErrorOf(e))
+	}
+	return errs
+}
+
+func retryEvent(op events.PhaseRetryOperation, attempt, maxAttempts int, message string) events.Event {
+	return events.Event{
+		Type: events.PhaseRetryType,
+		PhaseRetryEvent: events.PhaseRetryEvent{
+			Operation:   op,
+			Attempt:     attempt,
+			MaxAttempts: maxAttempts,
+			Message:     message,
+		},
+	}
+}
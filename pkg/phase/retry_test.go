@@ -0,0 +1,269 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/k8s/poller"
+	"opendev.org/airship/airshipctl/pkg/phase"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+// flakyRegistry returns executors which fail with the given error until the number of failures is reached
+func flakyRegistry(failures int, err error, runs *int) phase.ExecutorRegistry {
+	return func() map[schema.GroupVersionKind]ifc.ExecutorFactory {
+		gvk := schema.GroupVersionKind{
+			Group:   "airshipit.org",
+			Version: "v1alpha1",
+			Kind:    "Clusterctl",
+		}
+		return map[schema.GroupVersionKind]ifc.ExecutorFactory{
+			gvk: func(ifc.ExecutorConfig) (ifc.Executor, error) {
+				return flakyExecutor{failures: failures, err: err, runs: runs}, nil
+			},
+		}
+	}
+}
+
+type flakyExecutor struct {
+	fakeExecutor
+	failures int
+	err      error
+	runs     *int
+}
+
+func (e flakyExecutor) Run(_ context.Context, ch chan events.Event, _ ifc.RunOptions) {
+	defer close(ch)
+	*e.runs++
+	if *e.runs <= e.failures {
+		ch <- events.Event{
+			Type:       events.ErrorType,
+			ErrorEvent: events.ErrorEvent{Error: e.err},
+		}
+	}
+}
+
+// retryOperations returns attempt number and operation of each retry event
+func retryOperations(evts []events.Event) []events.PhaseRetryEvent {
+	ops := []events.PhaseRetryEvent{}
+	for _, e := range evts {
+		if e.Type == events.PhaseRetryType {
+			ops = append(ops, events.PhaseRetryEvent{
+				Operation: e.PhaseRetryEvent.Operation,
+				Attempt:   e.PhaseRetryEvent.Attempt,
+			})
+		}
+	}
+	return ops
+}
+
+func TestPhaseRunRetry(t *testing.T) {
+	testErr := errors.New("test error")
+	tests := []struct {
+		name         string
+		retry        *v1alpha1.PhaseRetryPolicy
+		failures     int
+		err          error
+		expectedRuns int
+		expectedOps  []events.PhaseRetryEvent
+		expectedErr  error
+	}{
+		{
+			name:         "Error executor is not retried without retry policy",
+			failures:     1,
+			err:          testErr,
+			expectedRuns: 1,
+			expectedOps:  []events.PhaseRetryEvent{},
+			expectedErr:  events.ErrEventReceived{Errors: []error{testErr}},
+		},
+		{
+			name:         "Success executor is retried until it succeeds",
+			retry:        &v1alpha1.PhaseRetryPolicy{MaxAttempts: 3},
+			failures:     2,
+			err:          testErr,
+			expectedRuns: 3,
+			expectedOps: []events.PhaseRetryEvent{
+				{Operation: events.PhaseRetryAttemptStart, Attempt: 1},
+				{Operation: events.PhaseRetryAttemptFailed, Attempt: 1},
+				{Operation: events.PhaseRetryAttemptStart, Attempt: 2},
+				{Operation: events.PhaseRetryAttemptFailed, Attempt: 2},
+				{Operation: events.PhaseRetryAttemptStart, Attempt: 3},
+			},
+		},
+		{
+			name:         "Error only the last failure is reported",
+			retry:        &v1alpha1.PhaseRetryPolicy{MaxAttempts: 2},
+			failures:     5,
+			err:          testErr,
+			expectedRuns: 2,
+			expectedOps: []events.PhaseRetryEvent{
+				{Operation: events.PhaseRetryAttemptStart, Attempt: 1},
+				{Operation: events.PhaseRetryAttemptFailed, Attempt: 1},
+				{Operation: events.PhaseRetryAttemptStart, Attempt: 2},
+			},
+			expectedErr: events.ErrEventReceived{Errors: []error{testErr}},
+		},
+		{
+			name: "Error error type is not retryable",
+			retry: &v1alpha1.PhaseRetryPolicy{
+				MaxAttempts: 3,
+				RetryOn:     []v1alpha1.PhaseRetryErrorType{v1alpha1.PhaseRetryOnTimeout},
+			},
+			failures:     1,
+			err:          testErr,
+			expectedRuns: 1,
+			expectedOps: []events.PhaseRetryEvent{
+				{Operation: events.PhaseRetryAttemptStart, Attempt: 1},
+			},
+			expectedErr: events.ErrEventReceived{Errors: []error{testErr}},
+		},
+		{
+			name: "Success wait timeout is retried",
+			retry: &v1alpha1.PhaseRetryPolicy{
+				MaxAttempts: 2,
+				RetryOn:     []v1alpha1.PhaseRetryErrorType{v1alpha1.PhaseRetryOnTimeout},
+			},
+			failures:     1,
+			err:          poller.ErrWaitTimeout{Timeout: 10, Resources: []string{"Deployment/default/test"}},
+			expectedRuns: 2,
+			expectedOps: []events.PhaseRetryEvent{
+				{Operation: events.PhaseRetryAttemptStart, Attempt: 1},
+				{Operation: events.PhaseRetryAttemptFailed, Attempt: 1},
+				{Operation: events.PhaseRetryAttemptStart, Attempt: 2},
+			},
+		},
+		{
+			name: "Success connection refused is retried",
+			retry: &v1alpha1.PhaseRetryPolicy{
+				MaxAttempts: 2,
+				RetryOn:     []v1alpha1.PhaseRetryErrorType{v1alpha1.PhaseRetryOnNetwork},
+			},
+			failures:     1,
+			err:          &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED},
+			expectedRuns: 2,
+			expectedOps: []events.PhaseRetryEvent{
+				{Operation: events.PhaseRetryAttemptStart, Attempt: 1},
+				{Operation: events.PhaseRetryAttemptFailed, Attempt: 1},
+				{Operation: events.PhaseRetryAttemptStart, Attempt: 2},
+			},
+		},
+		{
+			name: "Success API server error is retried",
+			retry: &v1alpha1.PhaseRetryPolicy{
+				MaxAttempts: 2,
+				RetryOn: []v1alpha1.PhaseRetryErrorType{
+					v1alpha1.PhaseRetryOnConflict,
+					v1alpha1.PhaseRetryOnServerError,
+				},
+			},
+			failures:     1,
+			err:          apierror.NewServiceUnavailable("etcd is not available"),
+			expectedRuns: 2,
+			expectedOps: []events.PhaseRetryEvent{
+				{Operation: events.PhaseRetryAttemptStart, Attempt: 1},
+				{Operation: events.PhaseRetryAttemptFailed, Attempt: 1},
+				{Operation: events.PhaseRetryAttemptStart, Attempt: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			helper, err := phase.NewHelper(testConfig(t))
+			require.NoError(t, err)
+			runs := 0
+			proc := &recordingProcessor{}
+			client := phase.NewClient(helper,
+				phase.InjectRegistry(flakyRegistry(tt.failures, tt.err, &runs)),
+				phase.InjectProcessor(func() events.EventProcessor { return proc }))
+
+			phaseObj, err := helper.Phase(ifc.ID{Name: "capi_init"})
+			require.NoError(t, err)
+			phaseObj.Config.Retry = tt.retry
+			p, err := client.PhaseByAPIObj(phaseObj)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedErr, p.Run(context.Background(), ifc.RunOptions{}))
+			assert.Equal(t, tt.expectedRuns, runs)
+			assert.Equal(t, tt.expectedOps, retryOperations(proc.events))
+		})
+	}
+}
+
+func TestPhaseValidateRetry(t *testing.T) {
+	tests := []struct {
+		name        string
+		retry       *v1alpha1.PhaseRetryPolicy
+		errContains string
+	}{
+		{
+			name: "Success valid retry policy",
+			retry: &v1alpha1.PhaseRetryPolicy{
+				MaxAttempts: 3,
+				Backoff:     10,
+				MaxBackoff:  60,
+				RetryOn:     []v1alpha1.PhaseRetryErrorType{v1alpha1.PhaseRetryOnNetwork},
+			},
+		},
+		{
+			name:        "Error negative max attempts",
+			retry:       &v1alpha1.PhaseRetryPolicy{MaxAttempts: -1},
+			errContains: "must not be negative",
+		},
+		{
+			name: "Error unknown error type",
+			retry: &v1alpha1.PhaseRetryPolicy{
+				RetryOn: []v1alpha1.PhaseRetryErrorType{"Transient"},
+			},
+			errContains: "unknown error type Transient",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			helper, err := phase.NewHelper(testConfig(t))
+			require.NoError(t, err)
+			client := phase.NewClient(helper, phase.InjectRegistry(fakeRegistry))
+
+			phaseObj, err := helper.Phase(ifc.ID{Name: "capi_init"})
+			require.NoError(t, err)
+			phaseObj.Config.Retry = tt.retry
+			p, err := client.PhaseByAPIObj(phaseObj)
+			require.NoError(t, err)
+
+			err = p.Validate()
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package remote
 
 import (
 	"fmt"
+	"time"
 )
 
 // TODO: This need to be refactored to match the error format used elsewhere in airshipctl
@@ -60,3 +61,60 @@ type ErrNoHostsFound struct{}
 func (e ErrNoHostsFound) Error() string {
 	return "no hosts selected"
 }
+
+// ErrBaremetalManagerNilBundle is returned when BaremetalManager executor is created without document bundle
+type ErrBaremetalManagerNilBundle struct{}
+
+func (e ErrBaremetalManagerNilBundle) Error() string {
+	return "cannot manage baremetal hosts, bundle is not defined"
+}
+
+// ErrBaremetalManagerNilConfig is returned when BaremetalManager executor is created without airship config
+type ErrBaremetalManagerNilConfig struct{}
+
+func (e ErrBaremetalManagerNilConfig) Error() string {
+	return "cannot manage baremetal hosts, airship config is not defined"
+}
+
+// ErrNoHostSelector is returned when neither host name nor label selector is defined by BaremetalManager
+type ErrNoHostSelector struct{}
+
+func (e ErrNoHostSelector) Error() string {
+	return "host selector must define either name or label selector"
+}
+
+// ErrNoBaremetalSteps is returned when BaremetalManager defines no steps to perform
+type ErrNoBaremetalSteps struct{}
+
+func (e ErrNoBaremetalSteps) Error() string {
+	return "at least one step must be defined"
+}
+
+// ErrUnknownBaremetalOperation is returned when step of BaremetalManager defines unsupported operation
+type ErrUnknownBaremetalOperation struct {
+	Operation string
+}
+
+func (e ErrUnknownBaremetalOperation) Error() string {
+	return fmt.Sprintf("unknown baremetal operation: %s", e.Operation)
+}
+
+// ErrUnknownPowerState is returned when step of BaremetalManager waits for unsupported power state
+type ErrUnknownPowerState struct {
+	State string
+}
+
+func (e ErrUnknownPowerState) Error() string {
+	return fmt.Sprintf("unknown power state %s, must be either on or off", e.State)
+}
+
+// ErrPowerStateTimeout is returned when host doesn't reach desired power state in time
+type ErrPowerStateTimeout struct {
+	HostName string
+	State    string
+	Timeout  time.Duration
+}
+
+func (e ErrPowerStateTimeout) Error() string {
+	return fmt.Sprintf("host %s did not reach power state %s in %s", e.HostName, e.State, e.Timeout)
+}
@@ -0,0 +1,296 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/power"
+)
+
+const (
+	// DefaultPowerStateTimeout is used if timeout is not set for the step waiting for a power state
+	DefaultPowerStateTimeout = 5 * time.Minute
+	// DefaultPowerStatePollInterval is an interval between host power status requests
+	DefaultPowerStatePollInterval = 5 * time.Second
+)
+
+var _ ifc.Executor = &Executor{}
+
+// RegisterExecutor adds executor to phase executor registry
+func RegisterExecutor(registry map[schema.GroupVersionKind]ifc.ExecutorFactory) error {
+	obj := &v1alpha1.BaremetalManager{}
+	gvks, _, err := v1alpha1.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return err
+	}
+	registry[gvks[0]] = NewExecutor
+	return nil
+}
+
+// Executor performs out-of-band operations on baremetal hosts defined in the phase documents
+type Executor struct {
+	ExecutorBundle document.Bundle
+
+	cfg          *config.Config
+	apiObj       *v1alpha1.BaremetalManager
+	newManager   func(*config.Config, document.Bundle, ...HostSelector) (*Manager, error)
+	pollInterval time.Duration
+}
+
+// NewExecutor creates instance of phase executor
+func NewExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
+	// management configuration of the hosts is taken from airship config
+	if cfg.AirshipConfig == nil {
+		return nil, ErrBaremetalManagerNilConfig{}
+	}
+	apiObj := &v1alpha1.BaremetalManager{}
+	err := cfg.ExecutorDocument.ToAPIObject(apiObj, v1alpha1.Scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := cfg.BundleFactory()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Executor{
+		ExecutorBundle: bundle,
+		cfg:            cfg.AirshipConfig,
+		apiObj:         apiObj,
+		newManager:     NewManager,
+		pollInterval:   DefaultPowerStatePollInterval,
+	}, nil
+}
+
+// Run performs the steps on every selected host, should be performed in separate go routine.
+// Steps are not started after the context is cancelled
+func (e *Executor) Run(ctx context.Context, ch chan events.Event, opts ifc.RunOptions) {
+	defer close(ch)
+
+	if e.ExecutorBundle == nil {
+		handleError(ch, ErrBaremetalManagerNilBundle{})
+		return
+	}
+
+	manager, err := e.newManager(e.cfg, e.ExecutorBundle, e.selectors()...)
+	if err != nil {
+		handleError(ch, err)
+		return
+	}
+
+	for _, step := range e.apiObj.Spec.Steps {
+		for _, host := range manager.Hosts {
+			if err = ctx.Err(); err != nil {
+				handleError(ch, err)
+				return
+			}
+			ch <- baremetalEvent(events.BaremetalManagerStart, host.HostName,
+				fmt.Sprintf("performing %s", step.Operation))
+
+			if opts.DryRun {
+				log.Printf("%s will be performed on host %s", step.Operation, host.HostName)
+			} else if err = e.runStep(ctx, host, step); err != nil {
+				handleError(ch, err)
+				return
+			}
+
+			ch <- baremetalEvent(events.BaremetalManagerComplete, host.HostName,
+				fmt.Sprintf("%s is completed", step.Operation))
+		}
+	}
+}
+
+// runStep performs the operation of the step on the host and waits for the power state if it is set
+func (e *Executor) runStep(ctx context.Context, host baremetalHost, step v1alpha1.BaremetalManagerStep) error {
+	var err error
+	switch step.Operation {
+	case v1alpha1.BaremetalOperationPowerOn:
+		err = host.SystemPowerOn(host.Context)
+	case v1alpha1.BaremetalOperationPowerOff:
+		err = host.SystemPowerOff(host.Context)
+	case v1alpha1.BaremetalOperationReboot:
+		err = host.RebootSystem(host.Context)
+	case v1alpha1.BaremetalOperationEjectVirtualMedia:
+		err = host.EjectVirtualMedia(host.Context)
+	case v1alpha1.BaremetalOperationRemoteDirect:
+		err = host.DoRemoteDirect(e.ExecutorBundle)
+	default:
+		err = ErrUnknownBaremetalOperation{Operation: string(step.Operation)}
+	}
+	if err != nil || step.WaitForPowerState == "" {
+		return err
+	}
+	return e.waitForPowerState(ctx, host, step)
+}
+
+// waitForPowerState polls host power status until it reaches the state defined by the step,
+// timeout expires or the context is cancelled
+func (e *Executor) waitForPowerState(
+	ctx context.Context,
+	host baremetalHost,
+	step v1alpha1.BaremetalManagerStep) error {
+	desired, err := powerStatus(step.WaitForPowerState)
+	if err != nil {
+		return err
+	}
+
+	timeout := DefaultPowerStateTimeout
+	if step.Timeout > 0 {
+		timeout = time.Duration(step.Timeout) * time.Second
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		status, statusErr := host.SystemPowerStatus(host.Context)
+		if statusErr != nil {
+			return statusErr
+		}
+		if status == desired {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return ErrPowerStateTimeout{HostName: host.HostName, State: desired.String(), Timeout: timeout}
+		}
+		log.Debugf("Host %s power status is %s, waiting for %s", host.HostName, status, desired)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(e.pollInterval):
+		}
+	}
+}
+
+// powerStatus converts power state of the step to the status reported by the management client
+func powerStatus(state v1alpha1.BaremetalPowerState) (power.Status, error) {
+	switch state {
+	case v1alpha1.BaremetalPowerStateOn:
+		return power.StatusOn, nil
+	case v1alpha1.BaremetalPowerStateOff:
+		return power.StatusOff, nil
+	default:
+		return power.StatusUnknown, ErrUnknownPowerState{State: string(state)}
+	}
+}
+
+// selectors returns host selectors built from the host selector of the executor document
+func (e *Executor) selectors() []HostSelector {
+	var selectors []HostSelector
+	if e.apiObj.Spec.HostSelector.Name != "" {
+		selectors = append(selectors, ByName(e.apiObj.Spec.HostSelector.Name))
+	}
+	if e.apiObj.Spec.HostSelector.LabelSelector != "" {
+		selectors = append(selectors, ByLabel(e.apiObj.Spec.HostSelector.LabelSelector))
+	}
+	return selectors
+}
+
+// Validate executor configuration and documents
+func (e *Executor) Validate() error {
+	if e.ExecutorBundle == nil {
+		return ErrBaremetalManagerNilBundle{}
+	}
+	if len(e.selectors()) == 0 {
+		return ErrNoHostSelector{}
+	}
+	if len(e.apiObj.Spec.Steps) == 0 {
+		return ErrNoBaremetalSteps{}
+	}
+	for _, step := range e.apiObj.Spec.Steps {
+		switch step.Operation {
+		case v1alpha1.BaremetalOperationPowerOn,
+			v1alpha1.BaremetalOperationPowerOff,
+			v1alpha1.BaremetalOperationReboot,
+			v1alpha1.BaremetalOperationEjectVirtualMedia,
+			v1alpha1.BaremetalOperationRemoteDirect:
+		default:
+			return ErrUnknownBaremetalOperation{Operation: string(step.Operation)}
+		}
+		if step.WaitForPowerState == "" {
+			continue
+		}
+		if _, err := powerStatus(step.WaitForPowerState); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Details returns summary of the operations performed by the executor
+func (e *Executor) Details() (string, error) {
+	hosts := []string{}
+	if e.apiObj.Spec.HostSelector.Name != "" {
+		hosts = append(hosts, fmt.Sprintf("name %s", e.apiObj.Spec.HostSelector.Name))
+	}
+	if e.apiObj.Spec.HostSelector.LabelSelector != "" {
+		hosts = append(hosts, fmt.Sprintf("labels %s", e.apiObj.Spec.HostSelector.LabelSelector))
+	}
+
+	steps := make([]string, 0, len(e.apiObj.Spec.Steps))
+	for _, step := range e.apiObj.Spec.Steps {
+		s := string(step.Operation)
+		if step.WaitForPowerState != "" {
+			s = fmt.Sprintf("%s (wait for power %s)", s, step.WaitForPowerState)
+		}
+		steps = append(steps, s)
+	}
+	return fmt.Sprintf("performs %s on baremetal hosts selected by %s",
+		strings.Join(steps, ", "), strings.Join(hosts, " and ")), nil
+}
+
+// Render document set
+func (e *Executor) Render(w io.Writer, o ifc.RenderOptions) error {
+	if e.ExecutorBundle == nil {
+		return ErrBaremetalManagerNilBundle{}
+	}
+	bundle, err := e.ExecutorBundle.SelectBundle(o.FilterSelector)
+	if err != nil {
+		return err
+	}
+	return bundle.Write(w)
+}
+
+func baremetalEvent(op events.BaremetalManagerOperation, hostName, message string) events.Event {
+	return events.Event{
+		Type: events.BaremetalManagerType,
+		BaremetalManagerEvent: events.BaremetalManagerEvent{
+			Operation: op,
+			HostName:  hostName,
+			Message:   message,
+		},
+	}
+}
+
+func handleError(ch chan<- events.Event, err error) {
+	ch <- events.Event{
+		Type: events.ErrorType,
+		ErrorEvent: events.ErrorEvent{
+			Error: err,
+		},
+	}
+}
@@ -0,0 +1,327 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/power"
+	"opendev.org/airship/airshipctl/testutil/redfishutils"
+)
+
+const (
+	baremetalManagerDoc = `
+apiVersion: airshipit.org/v1alpha1
+kind: BaremetalManager
+metadata:
+  name: reboot-ephemeral
+spec:
+  hostSelector:
+    labelSelector: airshipit.org/ephemeral-node=true
+  steps:
+  - operation: power-off
+    waitForPowerState: "off"
+    timeout: 1
+  - operation: eject-virtual-media
+`
+)
+
+func testBaremetalExecutor(t *testing.T, execDoc string) *Executor {
+	t.Helper()
+
+	doc, err := document.NewDocumentFromBytes([]byte(execDoc))
+	require.NoError(t, err)
+	executor, err := NewExecutor(ifc.ExecutorConfig{
+		ExecutorDocument: doc,
+		AirshipConfig:    initSettings(t),
+		BundleFactory: func() (document.Bundle, error) {
+			return testBundle(t, "base"), nil
+		},
+	})
+	require.NoError(t, err)
+	e, ok := executor.(*Executor)
+	require.True(t, ok)
+	e.pollInterval = time.Millisecond
+	return e
+}
+
+// withHosts makes executor use mocked clients instead of the ones defined by management configuration
+func withHosts(e *Executor, clients ...*redfishutils.MockClient) {
+	e.newManager = func(*config.Config, document.Bundle, ...HostSelector) (*Manager, error) {
+		manager := &Manager{}
+		for i, client := range clients {
+			manager.Hosts = append(manager.Hosts, baremetalHost{
+				Client:     client,
+				BMCAddress: redfishURL,
+				HostName:   fmt.Sprintf("node-%d", i),
+			})
+		}
+		return manager, nil
+	}
+}
+
+func TestRegisterBaremetalExecutor(t *testing.T) {
+	registry := make(map[schema.GroupVersionKind]ifc.ExecutorFactory)
+	expectedGVK := schema.GroupVersionKind{
+		Group:   "airshipit.org",
+		Version: "v1alpha1",
+		Kind:    "BaremetalManager",
+	}
+	err := RegisterExecutor(registry)
+	require.NoError(t, err)
+
+	_, found := registry[expectedGVK]
+	assert.True(t, found)
+}
+
+func TestNewBaremetalExecutorNilConfig(t *testing.T) {
+	doc, err := document.NewDocumentFromBytes([]byte(baremetalManagerDoc))
+	require.NoError(t, err)
+	_, err = NewExecutor(ifc.ExecutorConfig{
+		ExecutorDocument: doc,
+		BundleFactory: func() (document.Bundle, error) {
+			return testBundle(t, "base"), nil
+		},
+	})
+	assert.Equal(t, ErrBaremetalManagerNilConfig{}, err)
+}
+
+func TestBaremetalExecutorRun(t *testing.T) {
+	testErr := fmt.Errorf("power off error")
+	tests := []struct {
+		name          string
+		dryRun        bool
+		setupMock     func(*redfishutils.MockClient)
+		expectedTypes []events.Type
+		expectedErr   error
+	}{
+		{
+			name: "success",
+			setupMock: func(m *redfishutils.MockClient) {
+				m.On("SystemPowerOff", mock.Anything).Return(nil)
+				m.On("SystemPowerStatus", mock.Anything).Return(power.StatusPoweringOff, nil).Once()
+				m.On("SystemPowerStatus", mock.Anything).Return(power.StatusOff, nil)
+				m.On("EjectVirtualMedia").Return(nil)
+			},
+			expectedTypes: []events.Type{
+				events.BaremetalManagerType,
+				events.BaremetalManagerType,
+				events.BaremetalManagerType,
+				events.BaremetalManagerType,
+			},
+		},
+		{
+			name:      "dry run",
+			dryRun:    true,
+			setupMock: func(m *redfishutils.MockClient) {},
+			expectedTypes: []events.Type{
+				events.BaremetalManagerType,
+				events.BaremetalManagerType,
+				events.BaremetalManagerType,
+				events.BaremetalManagerType,
+			},
+		},
+		{
+			name: "operation error",
+			setupMock: func(m *redfishutils.MockClient) {
+				m.On("SystemPowerOff", mock.Anything).Return(testErr)
+			},
+			expectedTypes: []events.Type{
+				events.BaremetalManagerType,
+				events.ErrorType,
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "power state timeout",
+			setupMock: func(m *redfishutils.MockClient) {
+				m.On("SystemPowerOff", mock.Anything).Return(nil)
+				m.On("SystemPowerStatus", mock.Anything).Return(power.StatusOn, nil)
+			},
+			expectedTypes: []events.Type{
+				events.BaremetalManagerType,
+				events.ErrorType,
+			},
+			expectedErr: ErrPowerStateTimeout{HostName: "node-0", State: "OFF", Timeout: time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, rMock, err := redfishutils.NewClient(redfishURL, false, false, username, password)
+			require.NoError(t, err)
+			tt.setupMock(rMock)
+
+			executor := testBaremetalExecutor(t, baremetalManagerDoc)
+			withHosts(executor, rMock)
+			ch := make(chan events.Event)
+			go executor.Run(context.Background(), ch, ifc.RunOptions{DryRun: tt.dryRun})
+
+			var actualTypes []events.Type
+			var actualErr error
+			for evt := range ch {
+				actualTypes = append(actualTypes, evt.Type)
+				if evt.Type == events.ErrorType {
+					actualErr = evt.ErrorEvent.Error
+				}
+			}
+			assert.Equal(t, tt.expectedTypes, actualTypes)
+			assert.Equal(t, tt.expectedErr, actualErr)
+		})
+	}
+}
+
+func TestBaremetalExecutorRunNoHosts(t *testing.T) {
+	executor := testBaremetalExecutor(t, `
+apiVersion: airshipit.org/v1alpha1
+kind: BaremetalManager
+metadata:
+  name: no-hosts
+spec:
+  hostSelector:
+    name: bad-name
+  steps:
+  - operation: reboot
+`)
+	ch := make(chan events.Event)
+	go executor.Run(context.Background(), ch, ifc.RunOptions{})
+
+	var actualTypes []events.Type
+	for evt := range ch {
+		actualTypes = append(actualTypes, evt.Type)
+	}
+	assert.Equal(t, []events.Type{events.ErrorType}, actualTypes)
+}
+
+func TestBaremetalExecutorRunCancelled(t *testing.T) {
+	_, rMock, err := redfishutils.NewClient(redfishURL, false, false, username, password)
+	require.NoError(t, err)
+
+	executor := testBaremetalExecutor(t, baremetalManagerDoc)
+	withHosts(executor, rMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ch := make(chan events.Event)
+	go executor.Run(ctx, ch, ifc.RunOptions{})
+
+	var actualErrs []error
+	for evt := range ch {
+		if evt.Type == events.ErrorType {
+			actualErrs = append(actualErrs, evt.ErrorEvent.Error)
+		}
+	}
+	// mock client has no expectations, so the test fails if any operation is performed on the hosts
+	assert.Equal(t, []error{context.Canceled}, actualErrs)
+}
+
+func TestBaremetalExecutorValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		execDoc     string
+		expectedErr error
+	}{
+		{
+			name:    "success",
+			execDoc: baremetalManagerDoc,
+		},
+		{
+			name: "no host selector",
+			execDoc: `
+apiVersion: airshipit.org/v1alpha1
+kind: BaremetalManager
+metadata:
+  name: bmm
+spec:
+  steps:
+  - operation: reboot
+`,
+			expectedErr: ErrNoHostSelector{},
+		},
+		{
+			name: "no steps",
+			execDoc: `
+apiVersion: airshipit.org/v1alpha1
+kind: BaremetalManager
+metadata:
+  name: bmm
+spec:
+  hostSelector:
+    name: master-1
+`,
+			expectedErr: ErrNoBaremetalSteps{},
+		},
+		{
+			name: "unknown operation",
+			execDoc: `
+apiVersion: airshipit.org/v1alpha1
+kind: BaremetalManager
+metadata:
+  name: bmm
+spec:
+  hostSelector:
+    name: master-1
+  steps:
+  - operation: format-disk
+`,
+			expectedErr: ErrUnknownBaremetalOperation{Operation: "format-disk"},
+		},
+		{
+			name: "unknown power state",
+			execDoc: `
+apiVersion: airshipit.org/v1alpha1
+kind: BaremetalManager
+metadata:
+  name: bmm
+spec:
+  hostSelector:
+    name: master-1
+  steps:
+  - operation: power-on
+    waitForPowerState: sleeping
+`,
+			expectedErr: ErrUnknownPowerState{State: "sleeping"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			executor := testBaremetalExecutor(t, tt.execDoc)
+			assert.Equal(t, tt.expectedErr, executor.Validate())
+		})
+	}
+}
+
+func TestBaremetalExecutorDetails(t *testing.T) {
+	executor := testBaremetalExecutor(t, baremetalManagerDoc)
+	details, err := executor.Details()
+	require.NoError(t, err)
+	assert.Equal(t, "performs power-off (wait for power off), eject-virtual-media "+
+		"on baremetal hosts selected by labels airshipit.org/ephemeral-node=true", details)
+}
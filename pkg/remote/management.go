@@ -21,8 +21,6 @@ import (
 	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/log"
-	"opendev.org/airship/airshipctl/pkg/phase"
-	"opendev.org/airship/airshipctl/pkg/phase/ifc"
 	"opendev.org/airship/airshipctl/pkg/remote/power"
 	"opendev.org/airship/airshipctl/pkg/remote/redfish"
 	redfishdell "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/dell"
@@ -116,8 +114,8 @@ func ByName(name string) HostSelector {
 }
 
 // NewManager provides a manager that exposes the capability to perform remote direct functionality and other
-// out-of-band management on multiple hosts.
-func NewManager(cfg *config.Config, phaseName string, hosts ...HostSelector) (*Manager, error) {
+// out-of-band management on multiple hosts defined by baremetal host documents of the bundle.
+func NewManager(cfg *config.Config, docBundle document.Bundle, hosts ...HostSelector) (*Manager, error) {
 	managementCfg, err := cfg.CurrentContextManagementConfig()
 	if err != nil {
 		return nil, err
@@ -127,27 +125,6 @@ func NewManager(cfg *config.Config, phaseName string, hosts ...HostSelector) (*M
 		return nil, err
 	}
 
-	helper, err := phase.NewHelper(cfg)
-	if err != nil {
-		return nil, err
-	}
-
-	phaseClient := phase.NewClient(helper)
-	phase, err := phaseClient.PhaseByID(ifc.ID{Name: phaseName})
-	if err != nil {
-		return nil, err
-	}
-
-	docRoot, err := phase.DocumentRoot()
-	if err != nil {
-		return nil, err
-	}
-
-	docBundle, err := document.NewBundleByPath(docRoot)
-	if err != nil {
-		return nil, err
-	}
-
 	manager := &Manager{
 		Config: *managementCfg,
 		Hosts:  []baremetalHost{},
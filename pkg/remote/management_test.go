@@ -49,22 +49,19 @@ func withManagementConfig(cfg *config.ManagementConfiguration) Configuration {
 	}
 }
 
-// withTestDataPath sets the test data path when used as an argument to initSettings.
-func withTestDataPath(path string) Configuration {
-	return func(settings *config.Config) {
-		manifest, err := settings.CurrentContextManifest()
-		if err != nil {
-			panic(fmt.Sprintf("Unable to initialize management tests. Current Context error %q", err))
-		}
-		manifest.TargetPath = fmt.Sprintf("testdata/%s", path)
-		manifest.MetadataPath = "metadata.yaml"
-	}
+// testBundle returns bundle with baremetal host documents from the test data path.
+func testBundle(t *testing.T, path string) document.Bundle {
+	t.Helper()
+
+	bundle, err := document.NewBundleByPath(fmt.Sprintf("testdata/%s/manifests/site/test-site/ephemeral/bootstrap", path))
+	require.NoError(t, err)
+	return bundle
 }
 
 func TestNewManagerEphemeralHost(t *testing.T) {
-	settings := initSettings(t, withTestDataPath("base"))
+	settings := initSettings(t)
 
-	manager, err := NewManager(settings, config.BootstrapPhase, ByLabel(document.EphemeralHostSelector))
+	manager, err := NewManager(settings, testBundle(t, "base"), ByLabel(document.EphemeralHostSelector))
 	require.NoError(t, err)
 	require.Equal(t, 1, len(manager.Hosts))
 
@@ -72,9 +69,9 @@ func TestNewManagerEphemeralHost(t *testing.T) {
 }
 
 func TestNewManagerByName(t *testing.T) {
-	settings := initSettings(t, withTestDataPath("base"))
+	settings := initSettings(t)
 
-	manager, err := NewManager(settings, config.BootstrapPhase, ByName("master-1"))
+	manager, err := NewManager(settings, testBundle(t, "base"), ByName("master-1"))
 	require.NoError(t, err)
 	require.Equal(t, 1, len(manager.Hosts))
 
@@ -82,9 +79,9 @@ func TestNewManagerByName(t *testing.T) {
 }
 
 func TestNewManagerMultipleNodes(t *testing.T) {
-	settings := initSettings(t, withTestDataPath("base"))
+	settings := initSettings(t)
 
-	manager, err := NewManager(settings, config.BootstrapPhase, ByLabel("airshipit.org/test-node=true"))
+	manager, err := NewManager(settings, testBundle(t, "base"), ByLabel("airshipit.org/test-node=true"))
 	require.NoError(t, err)
 	require.Equal(t, 2, len(manager.Hosts))
 
@@ -93,9 +90,9 @@ func TestNewManagerMultipleNodes(t *testing.T) {
 }
 
 func TestNewManagerMultipleSelectors(t *testing.T) {
-	settings := initSettings(t, withTestDataPath("base"))
+	settings := initSettings(t)
 
-	manager, err := NewManager(settings, config.BootstrapPhase, ByName("master-1"),
+	manager, err := NewManager(settings, testBundle(t, "base"), ByName("master-1"),
 		ByLabel("airshipit.org/test-node=true"))
 	require.NoError(t, err)
 	require.Equal(t, 1, len(manager.Hosts))
@@ -104,9 +101,9 @@ func TestNewManagerMultipleSelectors(t *testing.T) {
 }
 
 func TestNewManagerMultipleSelectorsNoMatch(t *testing.T) {
-	settings := initSettings(t, withTestDataPath("base"))
+	settings := initSettings(t)
 
-	manager, err := NewManager(settings, config.BootstrapPhase, ByName("master-2"),
+	manager, err := NewManager(settings, testBundle(t, "base"), ByName("master-2"),
 		ByLabel(document.EphemeralHostSelector))
 
 	// Must return ErrNoHostsFound here, without check for specific error, test can panic
@@ -116,67 +113,60 @@ func TestNewManagerMultipleSelectorsNoMatch(t *testing.T) {
 }
 
 func TestNewManagerByNameNoHostFound(t *testing.T) {
-	settings := initSettings(t, withTestDataPath("base"))
+	settings := initSettings(t)
 
-	_, err := NewManager(settings, config.BootstrapPhase, ByName("bad-name"))
+	_, err := NewManager(settings, testBundle(t, "base"), ByName("bad-name"))
 	assert.Error(t, err)
 }
 
 func TestNewManagerNoSelectors(t *testing.T) {
-	settings := initSettings(t, withTestDataPath("base"))
+	settings := initSettings(t)
 
-	_, err := NewManager(settings, config.BootstrapPhase)
+	_, err := NewManager(settings, testBundle(t, "base"))
 	assert.Error(t, err)
 }
 
 func TestNewManagerByLabelNoHostsFound(t *testing.T) {
-	settings := initSettings(t, withTestDataPath("base"))
+	settings := initSettings(t)
 
-	_, err := NewManager(settings, config.BootstrapPhase, ByLabel("bad-label=true"))
+	_, err := NewManager(settings, testBundle(t, "base"), ByLabel("bad-label=true"))
 	assert.Error(t, err)
 }
 
 func TestNewManagerRedfish(t *testing.T) {
 	cfg := &config.ManagementConfiguration{Type: redfish.ClientType}
-	settings := initSettings(t, withManagementConfig(cfg), withTestDataPath("base"))
+	settings := initSettings(t, withManagementConfig(cfg))
 
-	_, err := NewManager(settings, config.BootstrapPhase, ByLabel(document.EphemeralHostSelector))
+	_, err := NewManager(settings, testBundle(t, "base"), ByLabel(document.EphemeralHostSelector))
 	assert.NoError(t, err)
 }
 
 func TestNewManagerRedfishDell(t *testing.T) {
 	cfg := &config.ManagementConfiguration{Type: redfishdell.ClientType}
-	settings := initSettings(t, withManagementConfig(cfg), withTestDataPath("base"))
+	settings := initSettings(t, withManagementConfig(cfg))
 
-	_, err := NewManager(settings, config.BootstrapPhase, ByLabel(document.EphemeralHostSelector))
+	_, err := NewManager(settings, testBundle(t, "base"), ByLabel(document.EphemeralHostSelector))
 	assert.NoError(t, err)
 }
 
 func TestNewManagerUnknownRemoteType(t *testing.T) {
 	badCfg := &config.ManagementConfiguration{Type: "bad-remote-type"}
-	settings := initSettings(t, withManagementConfig(badCfg), withTestDataPath("base"))
+	settings := initSettings(t, withManagementConfig(badCfg))
 
-	_, err := NewManager(settings, config.BootstrapPhase, ByLabel(document.EphemeralHostSelector))
+	_, err := NewManager(settings, testBundle(t, "base"), ByLabel(document.EphemeralHostSelector))
 	assert.Error(t, err)
 }
 
 func TestNewManagerMissingBMCAddress(t *testing.T) {
-	settings := initSettings(t, withTestDataPath("emptyurl"))
+	settings := initSettings(t)
 
-	_, err := NewManager(settings, config.BootstrapPhase, ByLabel(document.EphemeralHostSelector))
+	_, err := NewManager(settings, testBundle(t, "emptyurl"), ByLabel(document.EphemeralHostSelector))
 	assert.Error(t, err)
 }
 
 func TestNewManagerMissingCredentials(t *testing.T) {
-	settings := initSettings(t, withTestDataPath("emptyurl"))
-
-	_, err := NewManager(settings, config.BootstrapPhase, ByName("no-creds"))
-	assert.Error(t, err)
-}
-
-func TestNewManagerBadPhase(t *testing.T) {
-	settings := initSettings(t, withTestDataPath("base"))
+	settings := initSettings(t)
 
-	_, err := NewManager(settings, "bad-phase", ByLabel(document.EphemeralHostSelector))
+	_, err := NewManager(settings, testBundle(t, "emptyurl"), ByName("no-creds"))
 	assert.Error(t, err)
 }
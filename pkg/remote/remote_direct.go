@@ -16,37 +16,13 @@ package remote
 
 import (
 	api "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
-	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/log"
-	"opendev.org/airship/airshipctl/pkg/phase"
-	"opendev.org/airship/airshipctl/pkg/phase/ifc"
 	"opendev.org/airship/airshipctl/pkg/remote/power"
 )
 
-// DoRemoteDirect bootstraps the ephemeral node.
-func (b baremetalHost) DoRemoteDirect(cfg *config.Config) error {
-	helper, err := phase.NewHelper(cfg)
-	if err != nil {
-		return err
-	}
-
-	phaseClient := phase.NewClient(helper)
-	phase, err := phaseClient.PhaseByID(ifc.ID{Name: config.BootstrapPhase})
-	if err != nil {
-		return err
-	}
-
-	docRoot, err := phase.DocumentRoot()
-	if err != nil {
-		return err
-	}
-
-	docBundle, err := document.NewBundleByPath(docRoot)
-	if err != nil {
-		return err
-	}
-
+// DoRemoteDirect bootstraps the ephemeral node using RemoteDirectConfiguration document from the bundle.
+func (b baremetalHost) DoRemoteDirect(docBundle document.Bundle) error {
 	remoteDirectConfiguration := &api.RemoteDirectConfiguration{}
 	selector, err := document.NewSelector().ByObject(remoteDirectConfiguration, api.Scheme)
 	if err != nil {
@@ -46,9 +46,8 @@ func TestDoRemoteDirectMissingConfigOpts(t *testing.T) {
 		password,
 	}
 
-	settings := initSettings(t, withTestDataPath("noremote"))
 	// there must be document.ErrDocNotFound
-	err = ephemeralHost.DoRemoteDirect(settings)
+	err = ephemeralHost.DoRemoteDirect(testBundle(t, "noremote"))
 	expectedErrorMessage := `document filtered by selector [Group="airshipit.org", Version="v1alpha1", ` +
 		`Kind="RemoteDirectConfiguration"] found no documents`
 	assert.Equal(t, expectedErrorMessage, fmt.Sprintf("%s", err))
@@ -71,8 +70,7 @@ func TestDoRemoteDirectMissingISOURL(t *testing.T) {
 		password,
 	}
 
-	settings := initSettings(t, withTestDataPath("noisourl"))
-	err = ephemeralHost.DoRemoteDirect(settings)
+	err = ephemeralHost.DoRemoteDirect(testBundle(t, "noisourl"))
 	expectedErrorMessage := `missing option: isoURL`
 	assert.Equal(t, expectedErrorMessage, fmt.Sprintf("%s", err))
 	assert.Error(t, err)
@@ -98,8 +96,7 @@ func TestDoRemoteDirectRedfish(t *testing.T) {
 		password,
 	}
 
-	settings := initSettings(t, withTestDataPath("base"))
-	err = ephemeralHost.DoRemoteDirect(settings)
+	err = ephemeralHost.DoRemoteDirect(testBundle(t, "base"))
 	assert.NoError(t, err)
 }
 
@@ -124,8 +121,7 @@ func TestDoRemoteDirectRedfishNodePoweredOff(t *testing.T) {
 		password,
 	}
 
-	settings := initSettings(t, withTestDataPath("base"))
-	err = ephemeralHost.DoRemoteDirect(settings)
+	err = ephemeralHost.DoRemoteDirect(testBundle(t, "base"))
 	assert.NoError(t, err)
 }
 
@@ -151,9 +147,7 @@ func TestDoRemoteDirectRedfishVirtualMediaError(t *testing.T) {
 		password,
 	}
 
-	settings := initSettings(t, withTestDataPath("base"))
-
-	err = ephemeralHost.DoRemoteDirect(settings)
+	err = ephemeralHost.DoRemoteDirect(testBundle(t, "base"))
 	_, ok := err.(redfish.ErrRedfishClient)
 	assert.True(t, ok)
 }
@@ -180,9 +174,7 @@ func TestDoRemoteDirectRedfishBootSourceError(t *testing.T) {
 		password,
 	}
 
-	settings := initSettings(t, withTestDataPath("base"))
-
-	err = ephemeralHost.DoRemoteDirect(settings)
+	err = ephemeralHost.DoRemoteDirect(testBundle(t, "base"))
 	_, ok := err.(redfish.ErrRedfishClient)
 	assert.True(t, ok)
 }
@@ -210,9 +202,7 @@ func TestDoRemoteDirectRedfishRebootError(t *testing.T) {
 		password,
 	}
 
-	settings := initSettings(t, withTestDataPath("base"))
-
-	err = ephemeralHost.DoRemoteDirect(settings)
+	err = ephemeralHost.DoRemoteDirect(testBundle(t, "base"))
 	_, ok := err.(redfish.ErrRedfishClient)
 	assert.True(t, ok)
 }